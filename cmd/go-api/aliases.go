@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"go-api/internal/config"
+	"go-api/internal/http-server/handlers/url/save"
+	"go-api/internal/lib/alias"
+)
+
+// newAliasGenerator returns the alias generator of the strategy chosen in
+// the config.
+func newAliasGenerator(cfg config.Aliases) (save.AliasGenerator, error) {
+	switch cfg.Strategy {
+	case "random":
+		return alias.NewRandom(cfg.Length, cfg.Alphabet)
+	case "base62":
+		return alias.Base62{}, nil
+	case "hashids":
+		return alias.NewHashids(cfg.Salt, cfg.MinLength), nil
+	case "words":
+		return alias.Words{}, nil
+	default:
+		return nil, fmt.Errorf("unknown alias strategy %q", cfg.Strategy)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"go-api/internal/http-server/handlers/goods/importer"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage/sqlite"
+)
+
+// importActor is recorded in the goods history for goods imported from
+// the command line.
+const importActor = "cli"
+
+// runImport implements the import subcommand:
+//
+//	go-api import [-format csv|jsonl] [-batch n] file
+//
+// It prints the import report as JSON and returns the process exit code.
+func runImport(log *slog.Logger, storage *sqlite.Storage, batchSize int, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "file format: csv or jsonl (default: by file extension)")
+	batch := fs.Int("batch", batchSize, "goods per transaction, 0 for a single transaction")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || *batch < 0 {
+		fmt.Fprintln(os.Stderr, "usage: go-api import [-format csv|jsonl] [-batch n] file")
+		return 2
+	}
+
+	path := fs.Arg(0)
+
+	if *format == "" {
+		*format = importer.FormatByExtension(filepath.Ext(path))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Error("failed to open import file", sl.Err(err))
+		return 1
+	}
+	defer f.Close()
+
+	report, err := importer.Import(log, f, *format, *batch, importActor, storage)
+	if err != nil {
+		log.Error("failed to import goods", sl.Err(err))
+		return 1
+	}
+
+	log.Info("goods imported",
+		slog.Int("total", report.Total),
+		slog.Int("imported", report.Imported),
+		slog.Int("failed", report.Failed),
+	)
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		log.Error("failed to write report", sl.Err(err))
+		return 1
+	}
+
+	if report.Failed > 0 {
+		return 1
+	}
+
+	return 0
+}
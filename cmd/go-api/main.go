@@ -1,13 +1,54 @@
 package main
 
 import (
+	"go-api/internal/blob/filesystem"
 	"go-api/internal/config"
+	cartItems "go-api/internal/http-server/handlers/cart/items"
+	cartRead "go-api/internal/http-server/handlers/cart/read"
+	cartSave "go-api/internal/http-server/handlers/cart/save"
+	categoryRead "go-api/internal/http-server/handlers/category/read"
+	categoryRemove "go-api/internal/http-server/handlers/category/remove"
+	categorySave "go-api/internal/http-server/handlers/category/save"
+	categoryUpdate "go-api/internal/http-server/handlers/category/update"
+	goodsCategories "go-api/internal/http-server/handlers/goods/categories"
+	goodsExporter "go-api/internal/http-server/handlers/goods/exporter"
+	goodsHistory "go-api/internal/http-server/handlers/goods/history"
+	goodsImporter "go-api/internal/http-server/handlers/goods/importer"
+	goodsPrices "go-api/internal/http-server/handlers/goods/prices"
+	goodsRead "go-api/internal/http-server/handlers/goods/read"
+	goodsRemove "go-api/internal/http-server/handlers/goods/remove"
 	goodsSave "go-api/internal/http-server/handlers/goods/save"
+	goodsSearch "go-api/internal/http-server/handlers/goods/search"
+	goodsStock "go-api/internal/http-server/handlers/goods/stock"
+	goodsTrash "go-api/internal/http-server/handlers/goods/trash"
+	goodsUpdate "go-api/internal/http-server/handlers/goods/update"
+	goodsVariants "go-api/internal/http-server/handlers/goods/variants"
+	imageRead "go-api/internal/http-server/handlers/image/read"
+	imageSave "go-api/internal/http-server/handlers/image/save"
+	orderRead "go-api/internal/http-server/handlers/order/read"
+	orderSave "go-api/internal/http-server/handlers/order/save"
+	orderStatus "go-api/internal/http-server/handlers/order/status"
+	promotionEvaluate "go-api/internal/http-server/handlers/promotion/evaluate"
+	promotionRead "go-api/internal/http-server/handlers/promotion/read"
+	promotionRemove "go-api/internal/http-server/handlers/promotion/remove"
+	promotionSave "go-api/internal/http-server/handlers/promotion/save"
 	"go-api/internal/http-server/handlers/redirect"
+	reviewRead "go-api/internal/http-server/handlers/review/read"
+	reviewSave "go-api/internal/http-server/handlers/review/save"
+	reviewStatus "go-api/internal/http-server/handlers/review/status"
+	shippingQuote "go-api/internal/http-server/handlers/shipping/quote"
+	urlHistory "go-api/internal/http-server/handlers/url/history"
+	urlList "go-api/internal/http-server/handlers/url/list"
 	"go-api/internal/http-server/handlers/url/remove"
 	"go-api/internal/http-server/handlers/url/save"
+	urlStats "go-api/internal/http-server/handlers/url/stats"
+	urlTrash "go-api/internal/http-server/handlers/url/trash"
+	urlUpdate "go-api/internal/http-server/handlers/url/update"
+	"go-api/internal/lib/clicks"
+	"go-api/internal/lib/janitor"
 	"go-api/internal/lib/logger/handlers/slogpretty"
 	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/throttle"
 	"go-api/internal/storage/sqlite"
 	"log/slog"
 	"net/http"
@@ -42,6 +83,41 @@ func main() {
 
 	_ = storage
 
+	// blob storage: local filesystem
+	images, err := filesystem.New(cfg.Images.Path)
+	if err != nil {
+		log.Error("failed to init image storage", sl.Err(err))
+		os.Exit(1)
+	}
+
+	// shipping: rate tables from the config
+	shippingRates, err := loadShippingRates(cfg.Shipping)
+	if err != nil {
+		log.Error("failed to init shipping rates", sl.Err(err))
+		os.Exit(1)
+	}
+
+	// aliases: generated for urls saved without one
+	aliases, err := newAliasGenerator(cfg.Aliases)
+	if err != nil {
+		log.Error("failed to init alias generator", sl.Err(err))
+		os.Exit(1)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(log, storage, cfg.Import.BatchSize, os.Args[2:]))
+	}
+
+	// clicks: recorded in the background for the url stats
+	clickRecorder := clicks.New(log, storage, cfg.Clicks.BufferSize, cfg.Clicks.BatchSize, cfg.Clicks.FlushInterval,
+		cfg.Clicks.IPSalt)
+
+	// janitor: clean up of expired urls
+	urlJanitor := janitor.New(log, storage, cfg.Janitor.Interval, cfg.Janitor.Purge)
+
+	// passwords: throttling of guesses on protected urls
+	passwordLimiter := throttle.New(cfg.Passwords.MaxAttempts, cfg.Passwords.Window)
+
 	// router: chi, chi-render
 	router := chi.NewRouter()
 
@@ -52,26 +128,144 @@ func main() {
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
 
-	router.Get("/{alias}", redirect.New(log, storage))
+	router.Get("/{alias}", redirect.New(log, storage, clickRecorder))
+	router.Post("/{alias}", redirect.NewUnlock(log, storage, clickRecorder, passwordLimiter))
 
 	router.Route("/url", func(r chi.Router) {
 		r.Use(middleware.BasicAuth("go-api", map[string]string{
 			cfg.HTTPServer.User: cfg.HTTPServer.Password,
 		}))
 
-		r.Post("/", save.New(log, storage))
+		r.Get("/", urlList.New(log, storage))
+		r.Post("/", save.New(log, storage, aliases))
+		r.Post("/batch", save.NewBatch(log, storage, aliases))
+		r.Put("/{alias}", urlUpdate.New(log, storage))
+		r.Patch("/{alias}", urlUpdate.NewPatch(log, storage))
 		r.Delete("/{alias}",
 			remove.New(log, storage))
+
+		r.Get("/trash", urlTrash.New(log, storage))
+		r.Delete("/trash", urlTrash.NewPurge(log, storage))
+		r.Post("/{alias}/restore", urlTrash.NewRestore(log, storage))
+		r.Get("/{alias}/stats", urlStats.New(log, storage))
+		r.Get("/{alias}/history", urlHistory.New(log, storage))
+		r.Post("/{alias}/history/{version}/rollback", urlHistory.NewRollback(log, storage))
 	})
 
 	router.Route("/goods", func(r chi.Router) {
+		r.Get("/", goodsRead.NewList(log, storage))
+		r.Get("/search", goodsSearch.New(log, storage))
+		r.Get("/export", goodsExporter.New(log, storage))
+		r.Get("/{id}", goodsRead.New(log, storage))
+		r.Get("/{id}/categories", goodsCategories.New(log, storage))
+		r.Get("/{id}/prices", goodsPrices.New(log, storage))
+		r.Get("/{id}/stock", goodsStock.New(log, storage))
+		r.Get("/{id}/variants", goodsVariants.New(log, storage))
+		r.Get("/{id}/reviews", reviewRead.New(log, storage))
+		r.Post("/{id}/reviews", reviewSave.New(log, storage))
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.BasicAuth("go-api", map[string]string{
+				cfg.HTTPServer.User: cfg.HTTPServer.Password,
+			}))
+
+			r.Post("/save", goodsSave.New(log, storage))
+			r.Post("/import", goodsImporter.New(log, storage, cfg.Import.BatchSize))
+			r.Put("/{id}", goodsUpdate.New(log, storage))
+			r.Patch("/{id}", goodsUpdate.NewPatch(log, storage))
+			r.Delete("/{id}", goodsRemove.New(log, storage))
+			r.Put("/{id}/categories", goodsCategories.NewSet(log, storage))
+			r.Put("/{id}/prices/{currency}", goodsPrices.NewSet(log, storage))
+			r.Delete("/{id}/prices/{currency}", goodsPrices.NewRemove(log, storage))
+			r.Post("/{id}/variants", goodsVariants.NewSave(log, storage))
+			r.Put("/{id}/variants/{sku}", goodsVariants.NewUpdate(log, storage))
+			r.Delete("/{id}/variants/{sku}", goodsVariants.NewRemove(log, storage))
+			r.Get("/{id}/history", goodsHistory.New(log, storage))
+			r.Post("/{id}/history/{revision}/restore", goodsHistory.NewRestore(log, storage))
+
+			r.Get("/trash", goodsTrash.New(log, storage))
+			r.Delete("/trash", goodsTrash.NewPurge(log, storage))
+			r.Post("/{id}/restore", goodsTrash.NewRestore(log, storage))
+
+			r.Get("/low-stock", goodsStock.NewLowStockReport(log, storage))
+			r.Get("/{id}/stock/movements", goodsStock.NewMovements(log, storage))
+			r.Put("/{id}/stock/threshold", goodsStock.NewThreshold(log, storage))
+			r.Post("/{id}/stock/{operation}", goodsStock.NewMove(log, storage))
+		})
+	})
+
+	router.Route("/categories", func(r chi.Router) {
+		r.Get("/", categoryRead.NewTree(log, storage))
+		r.Get("/{id}", categoryRead.New(log, storage))
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.BasicAuth("go-api", map[string]string{
+				cfg.HTTPServer.User: cfg.HTTPServer.Password,
+			}))
+
+			r.Post("/", categorySave.New(log, storage))
+			r.Put("/{id}", categoryUpdate.New(log, storage))
+			r.Delete("/{id}", categoryRemove.New(log, storage))
+		})
+	})
+
+	router.Route("/images", func(r chi.Router) {
+		r.Get("/{id}", imageRead.New(log, storage, images))
+		r.Get("/{id}/thumbnails/{size}", imageRead.NewThumbnail(log, storage, images))
+
+		r.With(middleware.BasicAuth("go-api", map[string]string{
+			cfg.HTTPServer.User: cfg.HTTPServer.Password,
+		})).Post("/", imageSave.New(log, storage, images, cfg.Images.MaxSize, cfg.Images.ThumbnailSizes))
+	})
+
+	// carts are addressed by unguessable ids and need no auth
+	router.Route("/carts", func(r chi.Router) {
+		r.Post("/", cartSave.New(log, storage))
+		r.Get("/{id}", cartRead.New(log, storage))
+		r.Post("/{id}/items", cartItems.NewAdd(log, storage))
+		r.Delete("/{id}/items/{itemId}", cartItems.NewRemove(log, storage))
+	})
+
+	router.Route("/orders", func(r chi.Router) {
+		r.Post("/", orderSave.New(log, storage))
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.BasicAuth("go-api", map[string]string{
+				cfg.HTTPServer.User: cfg.HTTPServer.Password,
+			}))
+
+			r.Get("/", orderRead.NewList(log, storage))
+			r.Get("/{id}", orderRead.New(log, storage))
+			r.Put("/{id}/status", orderStatus.New(log, storage))
+		})
+	})
+
+	router.Route("/promotions", func(r chi.Router) {
+		r.Post("/evaluate", promotionEvaluate.New(log, storage))
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.BasicAuth("go-api", map[string]string{
+				cfg.HTTPServer.User: cfg.HTTPServer.Password,
+			}))
+
+			r.Post("/", promotionSave.New(log, storage))
+			r.Get("/", promotionRead.NewList(log, storage))
+			r.Get("/{code}", promotionRead.New(log, storage))
+			r.Delete("/{id}", promotionRemove.New(log, storage))
+		})
+	})
+
+	router.Route("/reviews", func(r chi.Router) {
 		r.Use(middleware.BasicAuth("go-api", map[string]string{
 			cfg.HTTPServer.User: cfg.HTTPServer.Password,
 		}))
 
-		r.Post("/save", goodsSave.New(log, storage))
-		//r.Delete("/{alias}",
-		//	remove.New(log, storage))
+		r.Get("/", reviewRead.NewList(log, storage))
+		r.Put("/{id}/status", reviewStatus.New(log, storage))
+	})
+
+	router.Route("/shipping", func(r chi.Router) {
+		r.Post("/quote", shippingQuote.New(log, storage, shippingRates))
 	})
 
 	log.Info("starting server", slog.String("address", cfg.Address))
@@ -89,6 +283,9 @@ func main() {
 		log.Error("failed to start server")
 	}
 
+	urlJanitor.Close()
+	clickRecorder.Close()
+
 	log.Error("server stopped")
 }
 
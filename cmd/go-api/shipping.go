@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"go-api/internal/config"
+	"go-api/internal/lib/money"
+	"go-api/internal/lib/shipping"
+)
+
+// loadShippingRates builds the shipping rate table from the config, parsing
+// the prices in the currency of their zone.
+func loadShippingRates(cfg config.Shipping) (*shipping.Table, error) {
+	zones := make([]shipping.Zone, 0, len(cfg.Zones))
+
+	for _, z := range cfg.Zones {
+		zone := shipping.Zone{Name: z.Name, Currency: z.Currency}
+
+		for _, m := range z.Methods {
+			method := shipping.Method{Name: m.Name, Days: m.Days}
+
+			for _, r := range m.Rates {
+				price, err := money.Parse(r.Price, z.Currency)
+				if err != nil {
+					return nil, fmt.Errorf("shipping zone %q: method %q: %w", z.Name, m.Name, err)
+				}
+
+				method.Rates = append(method.Rates, shipping.Rate{MaxWeight: r.MaxWeight, Price: price})
+			}
+
+			zone.Methods = append(zone.Methods, method)
+		}
+
+		zones = append(zones, zone)
+	}
+
+	return shipping.New(zones)
+}
@@ -0,0 +1,16 @@
+package blob
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Storage keeps binary objects such as uploaded images under
+// slash-separated keys.
+type Storage interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,114 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go-api/internal/blob"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// Storage keeps blobs as files below a root directory.
+type Storage struct {
+	root string
+}
+
+func New(root string) (*Storage, error) {
+	const op = "blob.filesystem.New"
+
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{root: root}, nil
+}
+
+// Put writes the blob to a temporary file first and renames it into
+// place, so that a failed upload never leaves a partial blob behind.
+func (s *Storage) Put(key string, r io.Reader) error {
+	const op = "blob.filesystem.Put"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Get(key string) (io.ReadCloser, error) {
+	const op = "blob.filesystem.Get"
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, blob.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return f, nil
+}
+
+func (s *Storage) Delete(key string) error {
+	const op = "blob.filesystem.Delete"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return blob.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would
+// escape it.
+func (s *Storage) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
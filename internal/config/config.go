@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"time"
@@ -12,6 +14,13 @@ type Config struct {
 	Env         string `yaml:"env" env-default:"local" env-required:"true"`
 	StoragePath string `yaml:"storage_path" env-required:"true"`
 	HTTPServer  `yaml:"http_server"`
+	Import      Import    `yaml:"import"`
+	Images      Images    `yaml:"images"`
+	Clicks      Clicks    `yaml:"clicks"`
+	Janitor     Janitor   `yaml:"janitor"`
+	Passwords   Passwords `yaml:"passwords"`
+	Shipping    Shipping  `yaml:"shipping"`
+	Aliases     Aliases   `yaml:"aliases"`
 }
 
 type HTTPServer struct {
@@ -22,6 +31,88 @@ type HTTPServer struct {
 	Password    string        `yaml:"password" env-required:"true" env:"HTTP_SERVER_PASSWORD"`
 }
 
+type Import struct {
+	// BatchSize is the number of goods inserted per transaction by the bulk
+	// import; zero imports the whole file in a single transaction.
+	BatchSize int `yaml:"batch_size" env-default:"500"`
+}
+
+// MaxThumbnailSize is the largest side of a thumbnail, in pixels.
+const MaxThumbnailSize = 4096
+
+type Images struct {
+	Path           string `yaml:"path" env-default:"./storage/images"`
+	MaxSize        int64  `yaml:"max_size" env-default:"5242880"`
+	ThumbnailSizes []int  `yaml:"thumbnail_sizes" env-default:"128,512"`
+}
+
+// Clicks configures the recording of redirects. Clicks are buffered and
+// written in batches of BatchSize at least every FlushInterval; clicks
+// beyond BufferSize waiting to be written are dropped.
+type Clicks struct {
+	BufferSize    int           `yaml:"buffer_size" env-default:"1024"`
+	BatchSize     int           `yaml:"batch_size" env-default:"100"`
+	FlushInterval time.Duration `yaml:"flush_interval" env-default:"1s"`
+	// IPSalt keys the hashes of visitor IPs, so that they cannot be
+	// reversed by hashing every address.
+	IPSalt string `yaml:"ip_salt" env:"CLICKS_IP_SALT"`
+}
+
+// Janitor configures the clean up of expired urls every Interval. They
+// are moved to the trash, or purged if Purge is set.
+type Janitor struct {
+	Interval time.Duration `yaml:"interval" env-default:"10m"`
+	Purge    bool          `yaml:"purge" env-default:"false"`
+}
+
+// Passwords throttles password guesses on protected urls: once an alias
+// had MaxAttempts wrong passwords, it is refused for the rest of Window,
+// which starts at the first of them.
+type Passwords struct {
+	MaxAttempts int           `yaml:"max_attempts" env-default:"5"`
+	Window      time.Duration `yaml:"window" env-default:"15m"`
+}
+
+// Aliases configures the aliases generated for urls saved without one.
+// Strategy is one of:
+//   - random: Length characters drawn from Alphabet, which must not
+//     repeat any
+//   - base62: the id of the url in base 62
+//   - hashids: the id of the url as a hashid of at least MinLength
+//     characters, obfuscated by Salt
+//   - words: an adjective, a noun and a number, like brave-otter-42
+type Aliases struct {
+	Strategy  string `yaml:"strategy" env-default:"random"`
+	Length    int    `yaml:"length" env-default:"6"`
+	Alphabet  string `yaml:"alphabet" env-default:"abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"`
+	MinLength int    `yaml:"min_length" env-default:"6"`
+	Salt      string `yaml:"salt" env:"ALIASES_SALT"`
+}
+
+// Shipping holds the shipping rate tables of every destination zone.
+type Shipping struct {
+	Zones []ShippingZone `yaml:"zones"`
+}
+
+type ShippingZone struct {
+	Name     string           `yaml:"name"`
+	Currency string           `yaml:"currency"`
+	Methods  []ShippingMethod `yaml:"methods"`
+}
+
+type ShippingMethod struct {
+	Name  string         `yaml:"name"`
+	Days  int            `yaml:"days"`
+	Rates []ShippingRate `yaml:"rates"`
+}
+
+// ShippingRate is the price of shipments weighing up to MaxWeight, in the
+// unit of goods weight. Price is a decimal amount in the zone currency.
+type ShippingRate struct {
+	MaxWeight int64  `yaml:"max_weight"`
+	Price     string `yaml:"price"`
+}
+
 func MustLoad() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
@@ -39,5 +130,40 @@ func MustLoad() *Config {
 		log.Fatalf("cannot read config: %s", err)
 	}
 
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config: %s", err)
+	}
+
 	return &cfg
 }
+
+// Validate checks the settings the service could not run with.
+func (cfg *Config) Validate() error {
+	for _, size := range cfg.Images.ThumbnailSizes {
+		if size <= 0 || size > MaxThumbnailSize {
+			return fmt.Errorf("images.thumbnail_sizes must be between 1 and %d, got %d", MaxThumbnailSize, size)
+		}
+	}
+
+	if cfg.Clicks.FlushInterval <= 0 {
+		return errors.New("clicks.flush_interval must be positive")
+	}
+
+	if cfg.Janitor.Interval <= 0 {
+		return errors.New("janitor.interval must be positive")
+	}
+
+	if cfg.Passwords.MaxAttempts <= 0 {
+		return errors.New("passwords.max_attempts must be positive")
+	}
+
+	if cfg.Passwords.Window <= 0 {
+		return errors.New("passwords.window must be positive")
+	}
+
+	if cfg.Aliases.Strategy == "random" && cfg.Aliases.Length < 1 {
+		return errors.New("aliases.length must be positive")
+	}
+
+	return nil
+}
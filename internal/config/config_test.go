@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() Config {
+	return Config{
+		Images:    Images{ThumbnailSizes: []int{128, 512}},
+		Clicks:    Clicks{FlushInterval: time.Second},
+		Janitor:   Janitor{Interval: time.Minute},
+		Passwords: Passwords{MaxAttempts: 5, Window: time.Minute},
+		Aliases:   Aliases{Strategy: "random", Length: 6},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "Valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "Zero thumbnail size",
+			modify:  func(cfg *Config) { cfg.Images.ThumbnailSizes = []int{128, 0} },
+			wantErr: "images.thumbnail_sizes must be between 1 and 4096, got 0",
+		},
+		{
+			name:    "Huge thumbnail size",
+			modify:  func(cfg *Config) { cfg.Images.ThumbnailSizes = []int{100000} },
+			wantErr: "images.thumbnail_sizes must be between 1 and 4096, got 100000",
+		},
+		{
+			name:    "Zero clicks flush interval",
+			modify:  func(cfg *Config) { cfg.Clicks.FlushInterval = 0 },
+			wantErr: "clicks.flush_interval must be positive",
+		},
+		{
+			name:    "Negative clicks flush interval",
+			modify:  func(cfg *Config) { cfg.Clicks.FlushInterval = -time.Second },
+			wantErr: "clicks.flush_interval must be positive",
+		},
+		{
+			name:    "Zero janitor interval",
+			modify:  func(cfg *Config) { cfg.Janitor.Interval = 0 },
+			wantErr: "janitor.interval must be positive",
+		},
+		{
+			name:    "Zero password attempts",
+			modify:  func(cfg *Config) { cfg.Passwords.MaxAttempts = 0 },
+			wantErr: "passwords.max_attempts must be positive",
+		},
+		{
+			name:    "Negative password window",
+			modify:  func(cfg *Config) { cfg.Passwords.Window = -time.Minute },
+			wantErr: "passwords.window must be positive",
+		},
+		{
+			name:    "Zero random alias length",
+			modify:  func(cfg *Config) { cfg.Aliases.Length = 0 },
+			wantErr: "aliases.length must be positive",
+		},
+		{
+			name: "Alias length unused",
+			modify: func(cfg *Config) {
+				cfg.Aliases.Strategy = "base62"
+				cfg.Aliases.Length = 0
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := validConfig()
+			tc.modify(&cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package items
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/cart/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	GoodsId  string `json:"goodsId" validate:"required,number"`
+	Sku      string `json:"sku,omitempty" validate:"max=64"`
+	Quantity int64  `json:"quantity" validate:"min=1,max=10000"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CartItems
+type CartItems interface {
+	GetCart(id string) (storage.Cart, error)
+	AddCartItem(cartID string, item storage.LineItem) (int64, error)
+	RemoveCartItem(cartID string, itemID int64) error
+}
+
+// NewAdd adds goods, or a variant of them, to the cart and responds with
+// the updated cart.
+func NewAdd(log *slog.Logger, cartItems CartItems) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.cart.items.NewAdd"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		cartID := chi.URLParam(r, "id")
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		goodsID, err := strconv.ParseInt(req.GoodsId, 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		id, err := cartItems.AddCartItem(cartID, storage.LineItem{
+			GoodsID:  goodsID,
+			SKU:      req.Sku,
+			Quantity: req.Quantity,
+		})
+		if errors.Is(err, storage.ErrCartNotFound) {
+			log.Info("cart not found", slog.String("id", cartID))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("goods_id", goodsID))
+
+			render.JSON(w, r, resp.Error(storage.ErrGoodsNotFound.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrVariantNotFound) {
+			log.Info("variant not found", slog.Int64("goods_id", goodsID), slog.String("sku", req.Sku))
+
+			render.JSON(w, r, resp.Error(storage.ErrVariantNotFound.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrPriceNotFound) {
+			log.Info("goods not priced in cart currency", slog.Int64("goods_id", goodsID))
+
+			render.JSON(w, r, resp.Error("no price in the cart currency"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to add cart item", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to add item"))
+
+			return
+		}
+
+		log.Info("cart item added", slog.String("cart_id", cartID), slog.Int64("id", id))
+
+		read.RenderCart(w, r, log, cartItems, cartID)
+	}
+}
+
+// NewRemove removes a line from the cart and responds with the updated cart.
+func NewRemove(log *slog.Logger, cartItems CartItems) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.cart.items.NewRemove"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		cartID := chi.URLParam(r, "id")
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "itemId"), 10, 64)
+		if err != nil {
+			log.Info("invalid item id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		err = cartItems.RemoveCartItem(cartID, id)
+		if errors.Is(err, storage.ErrCartItemNotFound) {
+			log.Info("cart item not found", slog.String("cart_id", cartID), slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to remove cart item", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("cart item removed", slog.String("cart_id", cartID), slog.Int64("id", id))
+
+		read.RenderCart(w, r, log, cartItems, cartID)
+	}
+}
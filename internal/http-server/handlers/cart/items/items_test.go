@@ -0,0 +1,183 @@
+package items_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/cart/items"
+	"go-api/internal/http-server/handlers/cart/items/mocks"
+	"go-api/internal/http-server/handlers/cart/read"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var cart = storage.Cart{ID: "c1", Currency: "USD", Items: []storage.LineItem{
+	{ID: 1, GoodsID: 7, Title: "Tea", Price: 950, Weight: 100, Quantity: 2},
+}}
+
+func TestAddHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		item      storage.LineItem
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			body: `{"goodsId": "7", "quantity": 2}`,
+			item: storage.LineItem{GoodsID: 7, Quantity: 2},
+		},
+		{
+			name: "Variant",
+			body: `{"goodsId": "8", "sku": "SHIRT-M", "quantity": 1}`,
+			item: storage.LineItem{GoodsID: 8, SKU: "SHIRT-M", Quantity: 1},
+		},
+		{
+			name:      "Zero quantity",
+			body:      `{"goodsId": "7", "quantity": 0}`,
+			respError: "field Quantity is not valid",
+		},
+		{
+			name:      "Invalid goods id",
+			body:      `{"goodsId": "tea", "quantity": 1}`,
+			respError: "field GoodsId is not valid",
+		},
+		{
+			name:      "Cart not found",
+			body:      `{"goodsId": "7", "quantity": 1}`,
+			item:      storage.LineItem{GoodsID: 7, Quantity: 1},
+			respError: "not found",
+			mockError: storage.ErrCartNotFound,
+		},
+		{
+			name:      "Goods not found",
+			body:      `{"goodsId": "9", "quantity": 1}`,
+			item:      storage.LineItem{GoodsID: 9, Quantity: 1},
+			respError: storage.ErrGoodsNotFound.Error(),
+			mockError: storage.ErrGoodsNotFound,
+		},
+		{
+			name:      "Not priced",
+			body:      `{"goodsId": "7", "quantity": 1}`,
+			item:      storage.LineItem{GoodsID: 7, Quantity: 1},
+			respError: "no price in the cart currency",
+			mockError: storage.ErrPriceNotFound,
+		},
+		{
+			name:      "AddCartItem Error",
+			body:      `{"goodsId": "7", "quantity": 1}`,
+			item:      storage.LineItem{GoodsID: 7, Quantity: 1},
+			respError: "failed to add item",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cartItemsMock := mocks.NewCartItems(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				cartItemsMock.On("AddCartItem", "c1", tc.item).
+					Return(int64(1), tc.mockError).Once()
+			}
+			if tc.respError == "" {
+				cartItemsMock.On("GetCart", "c1").
+					Return(cart, nil).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/carts/{id}/items", items.NewAdd(slogdiscard.NewDiscardLogger(), cartItemsMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/carts/c1/items", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "19.00", resp.Total)
+			}
+		})
+	}
+}
+
+func TestRemoveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		itemID    string
+		respError string
+		mockError error
+	}{
+		{
+			name:   "Success",
+			itemID: "1",
+		},
+		{
+			name:      "Invalid id",
+			itemID:    "one",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not found",
+			itemID:    "1",
+			respError: "not found",
+			mockError: storage.ErrCartItemNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cartItemsMock := mocks.NewCartItems(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				cartItemsMock.On("RemoveCartItem", "c1", int64(1)).
+					Return(tc.mockError).Once()
+			}
+			if tc.respError == "" {
+				cartItemsMock.On("GetCart", "c1").
+					Return(storage.Cart{ID: "c1", Currency: "USD"}, nil).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Delete("/carts/{id}/items/{itemId}", items.NewRemove(slogdiscard.NewDiscardLogger(), cartItemsMock))
+
+			req, err := http.NewRequest(http.MethodDelete, "/carts/c1/items/"+tc.itemID, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
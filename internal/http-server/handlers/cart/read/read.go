@@ -0,0 +1,132 @@
+package read
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+// Cart is the JSON representation of a cart with totals computed from
+// the current catalog prices and weights.
+type Cart struct {
+	Id       string `json:"id"`
+	Currency string `json:"currency"`
+	Items    []Item `json:"items"`
+	Total    string `json:"total"`
+	Weight   int64  `json:"weight"`
+}
+
+// Item is a cart or order line. Price and Weight are per unit, Total is
+// the price of the whole line.
+type Item struct {
+	Id       string `json:"id,omitempty"`
+	GoodsId  string `json:"goodsId"`
+	Sku      string `json:"sku,omitempty"`
+	Title    string `json:"title"`
+	Quantity int64  `json:"quantity"`
+	Price    string `json:"price"`
+	Weight   int32  `json:"weight"`
+	Total    string `json:"total"`
+}
+
+type Response struct {
+	resp.Response
+	Cart
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CartGetter
+type CartGetter interface {
+	GetCart(id string) (storage.Cart, error)
+}
+
+func New(log *slog.Logger, cartGetter CartGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.cart.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		RenderCart(w, r, log, cartGetter, chi.URLParam(r, "id"))
+	}
+}
+
+// RenderCart responds with the cart, reporting a line that can no longer
+// be priced so that the client can remove it.
+func RenderCart(w http.ResponseWriter, r *http.Request, log *slog.Logger, cartGetter CartGetter, id string) {
+	cart, err := cartGetter.GetCart(id)
+	if errors.Is(err, storage.ErrCartNotFound) {
+		log.Info("cart not found", slog.String("id", id))
+
+		render.JSON(w, r, resp.Error("not found"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrPriceNotFound) || errors.Is(err, storage.ErrVariantNotFound) ||
+		errors.Is(err, storage.ErrGoodsNotFound) {
+		log.Info("cart has a stale item", sl.Err(err))
+
+		render.JSON(w, r, resp.Error(err.Error()))
+
+		return
+	}
+	if err != nil {
+		log.Error("failed to get cart", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return
+	}
+
+	log.Info("got cart", slog.String("id", id), slog.Int("items", len(cart.Items)))
+
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		Cart:     ToCart(cart),
+	})
+}
+
+// ToCart converts storage.Cart to its JSON representation.
+func ToCart(cart storage.Cart) Cart {
+	total, weight := cart.Total()
+
+	return Cart{
+		Id:       cart.ID,
+		Currency: cart.Currency,
+		Items:    ToItems(cart.Items, cart.Currency),
+		Total:    money.Format(total, cart.Currency),
+		Weight:   weight,
+	}
+}
+
+// ToItems converts cart or order lines priced in currency to their JSON
+// representation.
+func ToItems(items []storage.LineItem, currency string) []Item {
+	res := make([]Item, 0, len(items))
+
+	for _, item := range items {
+		res = append(res, Item{
+			Id:       strconv.FormatInt(item.ID, 10),
+			GoodsId:  strconv.FormatInt(item.GoodsID, 10),
+			Sku:      item.SKU,
+			Title:    item.Title,
+			Quantity: item.Quantity,
+			Price:    money.Format(item.Price, currency),
+			Weight:   item.Weight,
+			Total:    money.Format(item.Price*item.Quantity, currency),
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,88 @@
+package read_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/cart/read"
+	"go-api/internal/http-server/handlers/cart/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestReadHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		cart      storage.Cart
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			cart: storage.Cart{ID: "c1", Currency: "USD", Items: []storage.LineItem{
+				{ID: 1, GoodsID: 7, Title: "Tea", Price: 950, Weight: 100, Quantity: 2},
+				{ID: 2, GoodsID: 8, SKU: "SHIRT-M", Title: "Shirt", Price: 1500, Weight: 200, Quantity: 1},
+			}},
+		},
+		{
+			name:      "Not found",
+			respError: "not found",
+			mockError: storage.ErrCartNotFound,
+		},
+		{
+			name:      "Stale item",
+			respError: "price not found: cart item 2 in USD",
+			mockError: fmt.Errorf("%w: cart item 2 in USD", storage.ErrPriceNotFound),
+		},
+		{
+			name:      "GetCart Error",
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cartGetterMock := mocks.NewCartGetter(t)
+			cartGetterMock.On("GetCart", "c1").
+				Return(tc.cart, tc.mockError).Once()
+
+			r := chi.NewRouter()
+			r.Get("/carts/{id}", read.New(slogdiscard.NewDiscardLogger(), cartGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, "/carts/c1", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "34.00", resp.Total)
+				require.Equal(t, int64(400), resp.Weight)
+				require.Len(t, resp.Items, 2)
+				require.Equal(t, "19.00", resp.Items[0].Total)
+				require.Equal(t, "9.50", resp.Items[0].Price)
+				require.Equal(t, "SHIRT-M", resp.Items[1].Sku)
+			}
+		})
+	}
+}
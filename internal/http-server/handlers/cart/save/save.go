@@ -0,0 +1,105 @@
+package save
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/cart/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Currency string `json:"currency" validate:"required,len=3,uppercase"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CartSaver
+type CartSaver interface {
+	SaveCart(id string, currency string) error
+}
+
+// New creates an empty cart priced in the requested currency. The cart id
+// is random: whoever knows it may change the cart and place the order.
+func New(log *slog.Logger, cartSaver CartSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.cart.save.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		if _, err := money.Exponent(req.Currency); err != nil {
+			log.Info("invalid currency", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+
+		id, err := newID()
+		if err != nil {
+			log.Error("failed to generate cart id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		if err := cartSaver.SaveCart(id, req.Currency); err != nil {
+			log.Error("failed to create cart", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to create cart"))
+
+			return
+		}
+
+		log.Info("cart created", slog.String("id", id))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Cart:     read.ToCart(storage.Cart{ID: id, Currency: req.Currency}),
+		})
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
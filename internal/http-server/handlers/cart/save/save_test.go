@@ -0,0 +1,84 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/cart/read"
+	"go-api/internal/http-server/handlers/cart/save"
+	"go-api/internal/http-server/handlers/cart/save/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+)
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			body: `{"currency": "EUR"}`,
+		},
+		{
+			name:      "Missing currency",
+			body:      `{}`,
+			respError: "field Currency is a required field",
+		},
+		{
+			name:      "Unknown currency",
+			body:      `{"currency": "XYZ"}`,
+			respError: "unknown currency",
+		},
+		{
+			name:      "SaveCart Error",
+			body:      `{"currency": "EUR"}`,
+			respError: "failed to create cart",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cartSaverMock := mocks.NewCartSaver(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				cartSaverMock.On("SaveCart", mock.AnythingOfType("string"), "EUR").
+					Return(tc.mockError).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/carts", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			save.New(slogdiscard.NewDiscardLogger(), cartSaverMock).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Len(t, resp.Id, 32)
+				require.Equal(t, "EUR", resp.Currency)
+				require.Equal(t, "0.00", resp.Total)
+				require.Empty(t, resp.Items)
+			}
+		})
+	}
+}
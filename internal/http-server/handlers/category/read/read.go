@@ -0,0 +1,150 @@
+package read
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Category struct {
+	Id       string     `json:"id"`
+	Name     string     `json:"name"`
+	ParentId string     `json:"parentId,omitempty"`
+	Children []Category `json:"children,omitempty"`
+}
+
+type Response struct {
+	resp.Response
+	Category
+}
+
+type TreeResponse struct {
+	resp.Response
+	Categories []Category `json:"categories"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CategoryGetter
+type CategoryGetter interface {
+	GetCategory(id int64) (storage.Category, error)
+	ListCategories() ([]storage.Category, error)
+}
+
+func New(log *slog.Logger, categoryGetter CategoryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.category.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid category id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		category, err := categoryGetter.GetCategory(id)
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			log.Info("category not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get category", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got category", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Category: ToCategory(category),
+		})
+	}
+}
+
+// NewTree returns all categories nested under their parents.
+func NewTree(log *slog.Logger, categoryGetter CategoryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.category.read.NewTree"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		categories, err := categoryGetter.ListCategories()
+		if err != nil {
+			log.Error("failed to list categories", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed categories", slog.Int("count", len(categories)))
+
+		render.JSON(w, r, TreeResponse{
+			Response:   resp.OK(),
+			Categories: BuildTree(categories),
+		})
+	}
+}
+
+// BuildTree nests categories under their parents keeping the input order
+// among siblings. The input must contain every ancestor of every category.
+func BuildTree(categories []storage.Category) []Category {
+	children := make(map[int64][]storage.Category, len(categories))
+
+	for _, c := range categories {
+		children[c.ParentID] = append(children[c.ParentID], c)
+	}
+
+	var build func(parentID int64) []Category
+	build = func(parentID int64) []Category {
+		nodes := make([]Category, 0, len(children[parentID]))
+
+		for _, c := range children[parentID] {
+			node := ToCategory(c)
+			node.Children = build(c.ID)
+
+			nodes = append(nodes, node)
+		}
+
+		return nodes
+	}
+
+	return build(0)
+}
+
+// ToCategory converts storage.Category to its JSON representation.
+func ToCategory(c storage.Category) Category {
+	category := Category{
+		Id:   strconv.FormatInt(c.ID, 10),
+		Name: c.Name,
+	}
+
+	if c.ParentID != 0 {
+		category.ParentId = strconv.FormatInt(c.ParentID, 10)
+	}
+
+	return category
+}
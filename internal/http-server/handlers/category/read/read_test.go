@@ -0,0 +1,53 @@
+package read_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/category/read"
+	"go-api/internal/http-server/handlers/category/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestTreeHandler(t *testing.T) {
+	categoryGetterMock := mocks.NewCategoryGetter(t)
+
+	categoryGetterMock.On("ListCategories").
+		Return([]storage.Category{
+			{ID: 3, Name: "Black", ParentID: 2},
+			{ID: 4, Name: "Coffee"},
+			{ID: 5, Name: "Green", ParentID: 2},
+			{ID: 2, Name: "Tea"},
+			{ID: 6, Name: "Sencha", ParentID: 5},
+		}, nil).Once()
+
+	handler := read.NewTree(slogdiscard.NewDiscardLogger(), categoryGetterMock)
+
+	req, err := http.NewRequest(http.MethodGet, "/categories", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, rr.Code, http.StatusOK)
+
+	var resp read.TreeResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Empty(t, resp.Error)
+	require.Equal(t, []read.Category{
+		{Id: "4", Name: "Coffee"},
+		{Id: "2", Name: "Tea", Children: []read.Category{
+			{Id: "3", Name: "Black", ParentId: "2"},
+			{Id: "5", Name: "Green", ParentId: "2", Children: []read.Category{
+				{Id: "6", Name: "Sencha", ParentId: "5"},
+			}},
+		}},
+	}, resp.Categories)
+}
@@ -0,0 +1,68 @@
+package remove
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CategoryRemover
+type CategoryRemover interface {
+	DeleteCategory(id int64) error
+}
+
+func New(log *slog.Logger, categoryRemover CategoryRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.category.remove.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid category id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		err = categoryRemover.DeleteCategory(id)
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			log.Info("category not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrCategoryHasChildren) {
+			log.Info("category has subcategories", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error(storage.ErrCategoryHasChildren.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to remove category", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("category removed", slog.Int64("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,120 @@
+package save
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Name     string `json:"name" validate:"required"`
+	ParentId string `json:"parentId,omitempty" validate:"omitempty,number"`
+}
+
+type Response struct {
+	resp.Response
+	Id string `json:"id,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CategorySaver
+type CategorySaver interface {
+	SaveCategory(name string, parentID int64) (int64, error)
+}
+
+func New(log *slog.Logger, categorySaver CategorySaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.category.save.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		category, err := Validate(req)
+		if err != nil {
+			log.Error("invalid request", sl.Err(err))
+
+			render.JSON(w, r, ValidationResponse(err))
+
+			return
+		}
+
+		id, err := categorySaver.SaveCategory(category.Name, category.ParentID)
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			log.Info("parent category not found", slog.Int64("parent_id", category.ParentID))
+
+			render.JSON(w, r, resp.Error("parent category not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to add category", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to add category"))
+
+			return
+		}
+
+		log.Info("category added", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Id:       strconv.FormatInt(id, 10),
+		})
+	}
+}
+
+// Validate checks req and converts it to storage.Category.
+func Validate(req Request) (storage.Category, error) {
+	if err := validator.New().Struct(req); err != nil {
+		return storage.Category{}, err
+	}
+
+	var parentID int64
+
+	if req.ParentId != "" {
+		var err error
+
+		if parentID, err = strconv.ParseInt(req.ParentId, 10, 64); err != nil {
+			return storage.Category{}, err
+		}
+	}
+
+	return storage.Category{
+		Name:     req.Name,
+		ParentID: parentID,
+	}, nil
+}
+
+// ValidationResponse converts an error returned by Validate to a response.
+func ValidationResponse(err error) resp.Response {
+	var validateErr validator.ValidationErrors
+
+	if errors.As(err, &validateErr) {
+		return resp.ValidationError(validateErr)
+	}
+
+	return resp.Error("invalid request")
+}
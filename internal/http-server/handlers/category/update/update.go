@@ -0,0 +1,97 @@
+package update
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/category/read"
+	"go-api/internal/http-server/handlers/category/save"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=CategoryUpdater
+type CategoryUpdater interface {
+	UpdateCategory(category storage.Category) error
+}
+
+func New(log *slog.Logger, categoryUpdater CategoryUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.category.update.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid category id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req save.Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		category, err := save.Validate(req)
+		if err != nil {
+			log.Error("invalid request", sl.Err(err))
+
+			render.JSON(w, r, save.ValidationResponse(err))
+
+			return
+		}
+
+		category.ID = id
+
+		err = categoryUpdater.UpdateCategory(category)
+		if errors.Is(err, storage.ErrCategoryCycle) {
+			log.Info("category cycle", slog.Int64("id", id), slog.Int64("parent_id", category.ParentID))
+
+			render.JSON(w, r, resp.Error(storage.ErrCategoryCycle.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			log.Info("category not found", slog.Int64("id", id), sl.Err(err))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to update category", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to update category"))
+
+			return
+		}
+
+		log.Info("category updated", slog.Int64("id", id))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Category: read.ToCategory(category),
+		})
+	}
+}
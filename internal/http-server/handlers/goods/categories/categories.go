@@ -0,0 +1,175 @@
+package categories
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/category/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	CategoryIds []string `json:"categoryIds" validate:"dive,number"`
+}
+
+type Response struct {
+	resp.Response
+	Categories []read.Category `json:"categories"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsCategories
+type GoodsCategories interface {
+	GetGoodsCategories(goodsID int64) ([]storage.Category, error)
+	SetGoodsCategories(goodsID int64, categoryIDs []int64) error
+}
+
+// New lists the categories the goods is assigned to.
+func New(log *slog.Logger, goodsCategories GoodsCategories) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.categories.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		categories, err := goodsCategories.GetGoodsCategories(id)
+		if err != nil {
+			log.Error("failed to get goods categories", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got goods categories", slog.Int64("id", id), slog.Int("count", len(categories)))
+
+		responseOK(w, r, categories)
+	}
+}
+
+// NewSet replaces the categories the goods is assigned to.
+func NewSet(log *slog.Logger, goodsCategories GoodsCategories) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.categories.NewSet"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		categoryIDs := make([]int64, 0, len(req.CategoryIds))
+
+		for _, categoryId := range req.CategoryIds {
+			categoryID, err := strconv.ParseInt(categoryId, 10, 64)
+			if err != nil {
+				log.Error("invalid category id", sl.Err(err))
+
+				render.JSON(w, r, resp.Error("invalid request"))
+
+				return
+			}
+
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+
+		err = goodsCategories.SetGoodsCategories(id, categoryIDs)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			log.Info("category not found", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("category not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to set goods categories", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to set categories"))
+
+			return
+		}
+
+		log.Info("goods categories set", slog.Int64("id", id))
+
+		categories, err := goodsCategories.GetGoodsCategories(id)
+		if err != nil {
+			log.Error("failed to get goods categories", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		responseOK(w, r, categories)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, categories []storage.Category) {
+	res := Response{
+		Response:   resp.OK(),
+		Categories: make([]read.Category, 0, len(categories)),
+	}
+
+	for _, c := range categories {
+		res.Categories = append(res.Categories, read.ToCategory(c))
+	}
+
+	render.JSON(w, r, res)
+}
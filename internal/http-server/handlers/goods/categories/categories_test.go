@@ -0,0 +1,95 @@
+package categories_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/categories"
+	"go-api/internal/http-server/handlers/goods/categories/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestSetHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		categoryIDs []int64
+		respError   string
+		mockError   error
+	}{
+		{
+			name:        "Success",
+			body:        `{"categoryIds": ["2", "5"]}`,
+			categoryIDs: []int64{2, 5},
+		},
+		{
+			name:        "Clear",
+			body:        `{"categoryIds": []}`,
+			categoryIDs: []int64{},
+		},
+		{
+			name:      "Invalid id",
+			body:      `{"categoryIds": ["tea"]}`,
+			respError: "field CategoryIds[0] is not valid",
+		},
+		{
+			name:        "Unknown category",
+			body:        `{"categoryIds": ["42"]}`,
+			categoryIDs: []int64{42},
+			respError:   "category not found",
+			mockError:   fmt.Errorf("storage: %w", storage.ErrCategoryNotFound),
+		},
+		{
+			name:        "Unknown goods",
+			body:        `{"categoryIds": ["2"]}`,
+			categoryIDs: []int64{2},
+			respError:   "not found",
+			mockError:   storage.ErrGoodsNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsCategoriesMock := mocks.NewGoodsCategories(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsCategoriesMock.On("SetGoodsCategories", int64(1), tc.categoryIDs).
+					Return(tc.mockError).Once()
+			}
+
+			if tc.respError == "" {
+				goodsCategoriesMock.On("GetGoodsCategories", int64(1)).
+					Return([]storage.Category{}, nil).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Put("/goods/{id}/categories", categories.NewSet(slogdiscard.NewDiscardLogger(), goodsCategoriesMock))
+
+			req, err := http.NewRequest(http.MethodPut, "/goods/1/categories", bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp categories.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+// writeTimeout bounds the writing of every goods of an export.
+const writeTimeout = 10 * time.Second
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsExporter
+type GoodsExporter interface {
+	EachGoods(fn func(storage.Goods) error) error
+}
+
+// New streams the whole catalog in the format given by the format query
+// parameter: csv, jsonl or xml (a Google Merchant RSS feed).
+func New(log *slog.Logger, goodsExporter GoodsExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.exporter.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		format := r.URL.Query().Get("format")
+
+		f, ok := formats[format]
+		if !ok {
+			log.Info("unknown export format", slog.String("format", format))
+
+			render.JSON(w, r, resp.Error("unknown export format"))
+
+			return
+		}
+
+		// a full catalog takes longer to send than the server write timeout,
+		// so the deadline is moved on with every goods instead: a client
+		// that stops reading is still cut off
+		rc := http.NewResponseController(w)
+
+		extendDeadline := func() {
+			err := rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err != nil && !errors.Is(err, http.ErrNotSupported) {
+				log.Error("failed to set write deadline", sl.Err(err))
+			}
+		}
+
+		extendDeadline()
+
+		w.Header().Set("Content-Type", f.contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="goods.`+format+`"`)
+
+		fw, err := f.newWriter(w, baseURL(r))
+		if err != nil {
+			log.Error("failed to start export", sl.Err(err))
+
+			return
+		}
+
+		count := 0
+
+		err = goodsExporter.EachGoods(func(g storage.Goods) error {
+			count++
+
+			extendDeadline()
+
+			return fw.Write(g)
+		})
+		if err == nil {
+			err = fw.Close()
+		}
+		if err != nil {
+			// the status has already been sent, so the feed is just cut short
+			log.Error("failed to export goods", slog.Int("count", count), sl.Err(err))
+
+			return
+		}
+
+		log.Info("goods exported", slog.String("format", format), slog.Int("count", count))
+	}
+}
+
+// baseURL is the address of the API as seen by the client, used to link
+// feed items to their goods.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host
+}
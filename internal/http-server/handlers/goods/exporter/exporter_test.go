@@ -0,0 +1,135 @@
+package exporter_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/exporter"
+	"go-api/internal/http-server/handlers/goods/exporter/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var catalog = []storage.Goods{
+	{ID: 1, Title: "Tea", Price: 999, Currency: "USD", Description: "Green, loose", ImgUrl: "https://example.com/tea.png", Weight: 100},
+	{ID: 2, Title: "Matcha", Price: 1400, Currency: "JPY", ImageID: 7, Weight: 30},
+}
+
+func eachGoods(fn func(storage.Goods) error) error {
+	for _, g := range catalog {
+		if err := fn(g); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestExportHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		format      string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "CSV",
+			format:      "csv",
+			contentType: "text/csv; charset=utf-8",
+			body: "id,title,price,currency,description,imgUrl,imageId,weight\n" +
+				"1,Tea,9.99,USD,\"Green, loose\",https://example.com/tea.png,,100\n" +
+				"2,Matcha,1400,JPY,,,7,30\n",
+		},
+		{
+			name:        "JSONL",
+			format:      "jsonl",
+			contentType: "application/x-ndjson",
+			body: `{"id":"1","title":"Tea","price":"9.99","currency":"USD","description":"Green, loose","imgUrl":"https://example.com/tea.png","weight":100}` + "\n" +
+				`{"id":"2","title":"Matcha","price":"1400","currency":"JPY","imgUrl":"/images/7","imageId":"7","weight":30}` + "\n",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsExporterMock := mocks.NewGoodsExporter(t)
+			goodsExporterMock.On("EachGoods", mock.Anything).Return(eachGoods).Once()
+
+			rr := export(t, goodsExporterMock, tc.format)
+
+			require.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+			require.Equal(t, tc.body, rr.Body.String())
+		})
+	}
+}
+
+func TestExportXML(t *testing.T) {
+	goodsExporterMock := mocks.NewGoodsExporter(t)
+	goodsExporterMock.On("EachGoods", mock.Anything).Return(eachGoods).Once()
+
+	rr := export(t, goodsExporterMock, "xml")
+
+	var feed struct {
+		Channel struct {
+			Link  string `xml:"link"`
+			Items []struct {
+				ID             string `xml:"id"`
+				Link           string `xml:"link"`
+				ImageLink      string `xml:"image_link"`
+				Price          string `xml:"price"`
+				ShippingWeight string `xml:"shipping_weight"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+
+	require.NoError(t, xml.Unmarshal(rr.Body.Bytes(), &feed))
+
+	require.Equal(t, "http://example.com/goods", feed.Channel.Link)
+	require.Len(t, feed.Channel.Items, 2)
+	require.Equal(t, "1", feed.Channel.Items[0].ID)
+	require.Equal(t, "http://example.com/goods/1", feed.Channel.Items[0].Link)
+	require.Equal(t, "https://example.com/tea.png", feed.Channel.Items[0].ImageLink)
+	require.Equal(t, "9.99 USD", feed.Channel.Items[0].Price)
+	require.Equal(t, "1400 JPY", feed.Channel.Items[1].Price)
+	require.Equal(t, "http://example.com/images/7", feed.Channel.Items[1].ImageLink)
+	require.Equal(t, "30 g", feed.Channel.Items[1].ShippingWeight)
+}
+
+func TestExportFailure(t *testing.T) {
+	goodsExporterMock := mocks.NewGoodsExporter(t)
+	goodsExporterMock.On("EachGoods", mock.Anything).Return(errors.New("unexpected error")).Once()
+
+	rr := export(t, goodsExporterMock, "xml")
+
+	require.False(t, strings.HasSuffix(rr.Body.String(), "</rss>\n"))
+}
+
+func TestUnknownFormat(t *testing.T) {
+	rr := export(t, mocks.NewGoodsExporter(t), "pdf")
+
+	require.JSONEq(t, `{"status":"Error","error":"unknown export format"}`, rr.Body.String())
+}
+
+func export(t *testing.T, goodsExporter exporter.GoodsExporter, format string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/goods/export?format="+format, nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	exporter.New(slogdiscard.NewDiscardLogger(), goodsExporter).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	return rr
+}
@@ -0,0 +1,169 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	imageRead "go-api/internal/http-server/handlers/image/read"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+// feedWriter writes goods one by one as they are read from storage.
+type feedWriter interface {
+	Write(g storage.Goods) error
+	Close() error
+}
+
+type format struct {
+	contentType string
+	newWriter   func(w io.Writer, baseURL string) (feedWriter, error)
+}
+
+var formats = map[string]format{
+	"csv":   {contentType: "text/csv; charset=utf-8", newWriter: newCSVWriter},
+	"jsonl": {contentType: "application/x-ndjson", newWriter: newJSONLWriter},
+	"xml":   {contentType: "application/xml; charset=utf-8", newWriter: newXMLWriter},
+}
+
+// csvWriter uses the columns of the bulk import, so that an export can be
+// imported again.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, _ string) (feedWriter, error) {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"id", "title", "price", "currency", "description", "imgUrl", "imageId", "weight"}); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{w: cw}, nil
+}
+
+func (c *csvWriter) Write(g storage.Goods) error {
+	return c.w.Write([]string{
+		strconv.FormatInt(g.ID, 10),
+		g.Title,
+		money.Format(g.Price, g.Currency),
+		g.Currency,
+		g.Description,
+		g.ImgUrl,
+		formatID(g.ImageID),
+		strconv.FormatInt(int64(g.Weight), 10),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer, _ string) (feedWriter, error) {
+	return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+}
+
+func (j *jsonlWriter) Write(g storage.Goods) error {
+	return j.enc.Encode(read.ToGoods(g))
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+const (
+	xmlHeader = xml.Header +
+		`<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">` + "\n" +
+		"<channel>\n"
+	xmlFooter = "\n</channel>\n</rss>\n"
+)
+
+// xmlItem is a product of a Google Merchant feed. Weight is stored in grams.
+type xmlItem struct {
+	XMLName        xml.Name `xml:"item"`
+	ID             string   `xml:"g:id"`
+	Title          string   `xml:"g:title"`
+	Description    string   `xml:"g:description,omitempty"`
+	Link           string   `xml:"g:link"`
+	ImageLink      string   `xml:"g:image_link"`
+	Price          string   `xml:"g:price"`
+	ShippingWeight string   `xml:"g:shipping_weight"`
+}
+
+type xmlWriter struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	baseURL string
+}
+
+func newXMLWriter(w io.Writer, baseURL string) (feedWriter, error) {
+	if _, err := io.WriteString(w, xmlHeader); err != nil {
+		return nil, err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	// the channel element stays open until Close, so its own elements are
+	// encoded one by one before the items
+	channel := []struct{ name, value string }{
+		{"title", "go-api goods"},
+		{"link", baseURL + "/goods"},
+		{"description", "Goods catalog"},
+	}
+
+	for _, e := range channel {
+		if err := enc.EncodeElement(e.value, xml.StartElement{Name: xml.Name{Local: e.name}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &xmlWriter{w: w, enc: enc, baseURL: baseURL}, nil
+}
+
+func (x *xmlWriter) Write(g storage.Goods) error {
+	id := strconv.FormatInt(g.ID, 10)
+
+	imageLink := g.ImgUrl
+	if imageLink == "" && g.ImageID != 0 {
+		imageLink = x.baseURL + imageRead.URL(g.ImageID)
+	}
+
+	return x.enc.Encode(xmlItem{
+		ID:             id,
+		Title:          g.Title,
+		Description:    g.Description,
+		Link:           x.baseURL + "/goods/" + id,
+		ImageLink:      imageLink,
+		Price:          money.Format(g.Price, g.Currency) + " " + g.Currency,
+		ShippingWeight: strconv.FormatInt(int64(g.Weight), 10) + " g",
+	})
+}
+
+func (x *xmlWriter) Close() error {
+	if err := x.enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(x.w, xmlFooter)
+
+	return err
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,210 @@
+package history
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	"go-api/internal/lib/api/etag"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+// Revision is an entry of the goods history. Old is missing for created
+// goods and New for deleted ones.
+type Revision struct {
+	Id        string      `json:"id"`
+	Action    string      `json:"action"`
+	Actor     string      `json:"actor"`
+	Version   int64       `json:"version"`
+	Old       *read.Goods `json:"old,omitempty"`
+	New       *read.Goods `json:"new,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Revisions []Revision `json:"revisions"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsHistory
+type GoodsHistory interface {
+	ListGoodsHistory(goodsID int64, limit int, offset int) ([]storage.GoodsRevision, error)
+	RestoreGoods(goodsID int64, revisionID int64, actor string) (storage.Goods, error)
+}
+
+// New lists the history of the goods, newest first. The history of
+// deleted goods can be listed too.
+func New(log *slog.Logger, goodsHistory GoodsHistory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.history.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		revisions, err := goodsHistory.ListGoodsHistory(id, limit, offset)
+		if err != nil {
+			log.Error("failed to list goods history", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed goods history", slog.Int64("id", id), slog.Int("count", len(revisions)))
+
+		res := ListResponse{
+			Response:  resp.OK(),
+			Revisions: make([]Revision, 0, len(revisions)),
+		}
+
+		for _, rev := range revisions {
+			res.Revisions = append(res.Revisions, ToRevision(rev))
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// NewRestore brings the goods back to the state recorded by the revision
+// URL parameter, recreating it if it was deleted.
+func NewRestore(log *slog.Logger, goodsHistory GoodsHistory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.history.NewRestore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		revisionID, err := strconv.ParseInt(chi.URLParam(r, "revision"), 10, 64)
+		if err != nil {
+			log.Info("invalid revision id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		actor, _, _ := r.BasicAuth()
+
+		goods, err := goodsHistory.RestoreGoods(id, revisionID, actor)
+		if errors.Is(err, storage.ErrGoodsRevisionNotFound) {
+			log.Info("goods revision not found", slog.Int64("id", id), slog.Int64("revision", revisionID))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrImageNotFound) {
+			log.Info("image of the revision not found", slog.Int64("revision", revisionID))
+
+			render.JSON(w, r, resp.Error("image not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to restore goods"))
+
+			return
+		}
+
+		log.Info("goods restored", slog.Int64("id", id), slog.Int64("revision", revisionID))
+
+		w.Header().Set("ETag", etag.Format(goods.Version))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Goods:    read.ToGoods(goods),
+		})
+	}
+}
+
+// ToRevision converts storage.GoodsRevision to its JSON representation.
+func ToRevision(rev storage.GoodsRevision) Revision {
+	res := Revision{
+		Id:        strconv.FormatInt(rev.ID, 10),
+		Action:    rev.Action,
+		Actor:     rev.Actor,
+		Version:   rev.Version,
+		CreatedAt: rev.CreatedAt,
+	}
+
+	if rev.Old != nil {
+		old := read.ToGoods(*rev.Old)
+		res.Old = &old
+	}
+	if rev.New != nil {
+		goods := read.ToGoods(*rev.New)
+		res.New = &goods
+	}
+
+	return res
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
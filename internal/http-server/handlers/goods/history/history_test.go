@@ -0,0 +1,126 @@
+package history_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/history"
+	"go-api/internal/http-server/handlers/goods/history/mocks"
+	"go-api/internal/http-server/handlers/goods/read"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestListHandler(t *testing.T) {
+	goodsHistoryMock := mocks.NewGoodsHistory(t)
+
+	old := storage.Goods{ID: 1, Title: "Tea", Price: 999, Currency: "USD", Version: 1}
+	updated := storage.Goods{ID: 1, Title: "Tea", Price: 1099, Currency: "USD", Version: 2}
+
+	goodsHistoryMock.On("ListGoodsHistory", int64(1), 20, 0).
+		Return([]storage.GoodsRevision{
+			{ID: 2, GoodsID: 1, Action: storage.GoodsUpdated, Actor: "admin", Version: 2, Old: &old, New: &updated},
+			{ID: 1, GoodsID: 1, Action: storage.GoodsCreated, Actor: "admin", Version: 1, New: &old},
+		}, nil).Once()
+
+	r := chi.NewRouter()
+	r.Get("/goods/{id}/history", history.New(slogdiscard.NewDiscardLogger(), goodsHistoryMock))
+
+	req, err := http.NewRequest(http.MethodGet, "/goods/1/history", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	var resp history.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+	require.Len(t, resp.Revisions, 2)
+	require.Equal(t, "9.99", resp.Revisions[0].Old.Price)
+	require.Equal(t, "10.99", resp.Revisions[0].New.Price)
+	require.Equal(t, "admin", resp.Revisions[0].Actor)
+	require.Nil(t, resp.Revisions[1].Old)
+}
+
+func TestRestoreHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		revision  string
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			revision: "2",
+		},
+		{
+			name:      "Invalid revision",
+			revision:  "abc",
+			respError: "invalid request",
+		},
+		{
+			name:      "Revision not found",
+			revision:  "2",
+			respError: "not found",
+			mockError: storage.ErrGoodsRevisionNotFound,
+		},
+		{
+			name:      "Image gone",
+			revision:  "2",
+			respError: "image not found",
+			mockError: storage.ErrImageNotFound,
+		},
+		{
+			name:      "RestoreGoods Error",
+			revision:  "2",
+			respError: "failed to restore goods",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsHistoryMock := mocks.NewGoodsHistory(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsHistoryMock.On("RestoreGoods", int64(1), int64(2), "admin").
+					Return(storage.Goods{ID: 1, Title: "Tea", Price: 999, Currency: "USD", Version: 4}, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/goods/{id}/history/{revision}/restore", history.NewRestore(slogdiscard.NewDiscardLogger(), goodsHistoryMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/goods/1/history/"+tc.revision+"/restore", nil)
+			require.NoError(t, err)
+
+			req.SetBasicAuth("admin", "secret")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "9.99", resp.Price)
+				require.Equal(t, `"4"`, rr.Header().Get("ETag"))
+			}
+		})
+	}
+}
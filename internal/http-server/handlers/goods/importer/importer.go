@@ -0,0 +1,364 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/goods/save"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+const (
+	maxBodySize = 32 << 20
+	maxLineSize = 1 << 20
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format")
+	ErrInvalidHeader = errors.New("invalid csv header")
+)
+
+type RowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// Report describes the outcome of an import. Rows are numbered from 1 in
+// the order they are read; the CSV header and blank lines are not counted.
+type Report struct {
+	Total    int        `json:"total"`
+	Imported int        `json:"imported"`
+	Failed   int        `json:"failed"`
+	Errors   []RowError `json:"errors"`
+}
+
+type Response struct {
+	resp.Response
+	Report
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsBatchSaver
+type GoodsBatchSaver interface {
+	SaveGoodsBatch(goods []storage.Goods, actor string) ([]int64, error)
+}
+
+// New imports goods from a CSV or JSON Lines request body. The format is
+// taken from the format query parameter or the Content-Type header, and
+// the batch query parameter overrides batchSize.
+func New(log *slog.Logger, goodsSaver GoodsBatchSaver, batchSize int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.importer.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = formatByContentType(r.Header.Get("Content-Type"))
+		}
+
+		batch := batchSize
+		if v := r.URL.Query().Get("batch"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				log.Info("invalid batch size", slog.String("batch", v))
+
+				render.JSON(w, r, resp.Error("invalid request"))
+
+				return
+			}
+
+			batch = n
+		}
+
+		actor, _, _ := r.BasicAuth()
+
+		report, err := Import(log, http.MaxBytesReader(w, r.Body, maxBodySize), format, batch, actor, goodsSaver)
+		if errors.Is(err, ErrUnknownFormat) || errors.Is(err, ErrInvalidHeader) {
+			log.Info("import rejected", slog.String("format", format), sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to import goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to read import"))
+
+			return
+		}
+
+		log.Info("goods imported",
+			slog.Int("total", report.Total),
+			slog.Int("imported", report.Imported),
+			slog.Int("failed", report.Failed),
+		)
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Report:   report,
+		})
+	}
+}
+
+// Import validates every row of src with the rules of goods/save.Request
+// and saves the valid ones in transactions of batchSize goods, or in a
+// single transaction if batchSize is zero. The rows of a batch that fails
+// are saved one by one, so that only the rows that fail themselves are
+// listed in the report along with the invalid ones; an error is only returned if src
+// cannot be read at all. The goods are recorded in the history as created
+// by actor.
+func Import(log *slog.Logger, src io.Reader, format string, batchSize int, actor string, goodsSaver GoodsBatchSaver) (Report, error) {
+	next, err := rowReader(src, format)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Errors: []RowError{}}
+
+	var (
+		batch []storage.Goods
+		rows  []int
+	)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		_, err := goodsSaver.SaveGoodsBatch(batch, actor)
+		if err != nil && len(batch) > 1 {
+			// a single bad row fails the whole batch, so that each row is
+			// saved on its own to find out which ones and why
+			log.Warn("failed to save goods batch, saving its rows one by one", slog.Int("from_row", rows[0]), sl.Err(err))
+
+			for i, goods := range batch {
+				if _, err := goodsSaver.SaveGoodsBatch([]storage.Goods{goods}, actor); err != nil {
+					report.Errors = append(report.Errors, RowError{Row: rows[i], Error: saveError(log, rows[i], err)})
+					report.Failed++
+
+					continue
+				}
+
+				report.Imported++
+			}
+		} else if err != nil {
+			report.Errors = append(report.Errors, RowError{Row: rows[0], Error: saveError(log, rows[0], err)})
+			report.Failed++
+		} else {
+			report.Imported += len(rows)
+		}
+
+		batch, rows = batch[:0], rows[:0]
+	}
+
+	for row := 1; ; row++ {
+		req, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		report.Total++
+
+		var rowErr *rowError
+		if errors.As(err, &rowErr) {
+			report.Errors = append(report.Errors, RowError{Row: row, Error: rowErr.Error()})
+			report.Failed++
+
+			continue
+		}
+		if err != nil {
+			return Report{}, err
+		}
+
+		goods, err := save.Validate(req)
+		if err != nil {
+			report.Errors = append(report.Errors, RowError{Row: row, Error: save.ValidationResponse(err).Error})
+			report.Failed++
+
+			continue
+		}
+
+		batch = append(batch, goods)
+		rows = append(rows, row)
+
+		if batchSize > 0 && len(batch) == batchSize {
+			flush()
+		}
+	}
+
+	flush()
+
+	return report, nil
+}
+
+// saveError is the report message of a row that failed to be saved.
+func saveError(log *slog.Logger, row int, err error) string {
+	if errors.Is(err, storage.ErrImageNotFound) {
+		return "image not found"
+	}
+
+	log.Error("failed to save goods", slog.Int("row", row), sl.Err(err))
+
+	return "failed to save goods"
+}
+
+// FormatByExtension guesses the import format from a file name extension.
+func FormatByExtension(ext string) string {
+	switch ext {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	default:
+		return ""
+	}
+}
+
+func formatByContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV
+	case "application/jsonl", "application/x-ndjson", "application/x-jsonlines":
+		return FormatJSONL
+	default:
+		return ""
+	}
+}
+
+// rowError is a problem with a single row that does not stop the import.
+type rowError struct {
+	msg string
+}
+
+func (e *rowError) Error() string {
+	return e.msg
+}
+
+// rowReader returns a function that reads the next row of src, returning
+// io.EOF after the last one.
+func rowReader(src io.Reader, format string) (func() (save.Request, error), error) {
+	switch format {
+	case FormatCSV:
+		return csvReader(src)
+	case FormatJSONL:
+		return jsonlReader(src), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// csvReader reads CSV with a header row naming the JSON fields of
+// goods/save.Request.
+func csvReader(src io.Reader) (func() (save.Request, error), error) {
+	r := csv.NewReader(src)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return func() (save.Request, error) { return save.Request{}, io.EOF }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+
+	// spreadsheet software likes to start UTF-8 files with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	setters := make([]func(*save.Request, string), 0, len(header))
+
+	for _, column := range header {
+		set, ok := csvColumns[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidHeader, column)
+		}
+
+		setters = append(setters, set)
+	}
+
+	return func() (save.Request, error) {
+		record, err := r.Read()
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return save.Request{}, &rowError{msg: parseErr.Err.Error()}
+		}
+		if err != nil {
+			return save.Request{}, err
+		}
+
+		var req save.Request
+
+		for i, value := range record {
+			setters[i](&req, value)
+		}
+
+		return req, nil
+	}, nil
+}
+
+// csvColumns sets the goods/save.Request field of each CSV column. The id
+// column written by the export is ignored, so that exports can be imported.
+var csvColumns = map[string]func(*save.Request, string){
+	"id":          func(*save.Request, string) {},
+	"title":       func(r *save.Request, v string) { r.Title = v },
+	"price":       func(r *save.Request, v string) { r.Price = v },
+	"currency":    func(r *save.Request, v string) { r.Currency = v },
+	"description": func(r *save.Request, v string) { r.Description = v },
+	"imgUrl":      func(r *save.Request, v string) { r.ImgUrl = v },
+	"imageId":     func(r *save.Request, v string) { r.ImageId = v },
+	"weight":      func(r *save.Request, v string) { r.Weight = v },
+}
+
+// jsonlReader reads one goods/save.Request JSON object per line, skipping
+// blank lines.
+func jsonlReader(src io.Reader) func() (save.Request, error) {
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return func() (save.Request, error) {
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			var req save.Request
+
+			if err := json.Unmarshal(line, &req); err != nil {
+				return save.Request{}, &rowError{msg: "failed to decode row"}
+			}
+
+			return req, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return save.Request{}, err
+		}
+
+		return save.Request{}, io.EOF
+	}
+}
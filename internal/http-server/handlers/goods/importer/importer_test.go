@@ -0,0 +1,178 @@
+package importer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/importer"
+	"go-api/internal/http-server/handlers/goods/importer/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+const csvBody = "\ufefftitle,price,currency,imgUrl,weight\n" +
+	"Tea,9.99,USD,https://example.com/tea.png,100\n" +
+	"Coffee,cheap,USD,https://example.com/coffee.png,250\n" +
+	"Matcha,1400,JPY,https://example.com/matcha.png,30\n" +
+	"Cocoa,4.5\n"
+
+const jsonlBody = `{"title":"Tea","price":"9.99","currency":"USD","imgUrl":"https://example.com/tea.png","weight":"100"}
+
+{"title":"Coffee"
+{"title":"Matcha","price":"1400.5","currency":"JPY","imgUrl":"https://example.com/matcha.png","weight":"30"}
+`
+
+func TestImportHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		batches     []int
+		batchErrors []error // of each batch, in order
+		report      importer.Report
+		respError   string
+	}{
+		{
+			name:    "CSV",
+			query:   "?format=csv",
+			body:    csvBody,
+			batches: []int{2},
+			report: importer.Report{Total: 4, Imported: 2, Failed: 2, Errors: []importer.RowError{
+				{Row: 2, Error: "failed parse price value"},
+				{Row: 4, Error: "wrong number of fields"},
+			}},
+		},
+		{
+			name:        "JSONL by content type",
+			contentType: "application/x-ndjson",
+			body:        jsonlBody,
+			batches:     []int{1},
+			report: importer.Report{Total: 3, Imported: 1, Failed: 2, Errors: []importer.RowError{
+				{Row: 2, Error: "failed to decode row"},
+				{Row: 3, Error: "too many decimal places for currency"},
+			}},
+		},
+		{
+			name:    "Batches",
+			query:   "?format=csv&batch=1",
+			body:    csvBody,
+			batches: []int{1, 1},
+			report: importer.Report{Total: 4, Imported: 2, Failed: 2, Errors: []importer.RowError{
+				{Row: 2, Error: "failed parse price value"},
+				{Row: 4, Error: "wrong number of fields"},
+			}},
+		},
+		{
+			name:        "Failed batch",
+			query:       "?format=csv",
+			body:        csvBody,
+			batches:     []int{2, 1, 1},
+			batchErrors: []error{errors.New("unexpected error"), errors.New("unexpected error"), errors.New("unexpected error")},
+			report: importer.Report{Total: 4, Imported: 0, Failed: 4, Errors: []importer.RowError{
+				{Row: 2, Error: "failed parse price value"},
+				{Row: 4, Error: "wrong number of fields"},
+				{Row: 1, Error: "failed to save goods"},
+				{Row: 3, Error: "failed to save goods"},
+			}},
+		},
+		{
+			name:        "Missing image",
+			query:       "?format=csv",
+			body:        csvBody,
+			batches:     []int{2, 1, 1},
+			batchErrors: []error{storage.ErrImageNotFound, nil, storage.ErrImageNotFound},
+			report: importer.Report{Total: 4, Imported: 1, Failed: 3, Errors: []importer.RowError{
+				{Row: 2, Error: "failed parse price value"},
+				{Row: 4, Error: "wrong number of fields"},
+				{Row: 3, Error: "image not found"},
+			}},
+		},
+		{
+			name:        "Failed single row batch",
+			query:       "?format=csv&batch=1",
+			body:        csvBody,
+			batches:     []int{1, 1},
+			batchErrors: []error{nil, storage.ErrImageNotFound},
+			report: importer.Report{Total: 4, Imported: 1, Failed: 3, Errors: []importer.RowError{
+				{Row: 2, Error: "failed parse price value"},
+				{Row: 3, Error: "image not found"},
+				{Row: 4, Error: "wrong number of fields"},
+			}},
+		},
+		{
+			name:      "Unknown format",
+			query:     "?format=xlsx",
+			body:      csvBody,
+			respError: "unknown import format",
+		},
+		{
+			name:      "Unknown column",
+			query:     "?format=csv",
+			body:      "title,colour\nTea,green\n",
+			respError: `invalid csv header: unknown column "colour"`,
+		},
+		{
+			name:      "Invalid batch",
+			query:     "?format=csv&batch=-1",
+			body:      csvBody,
+			respError: "invalid request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsSaverMock := mocks.NewGoodsBatchSaver(t)
+
+			for i, size := range tc.batches {
+				size := size
+
+				var batchErr error
+				if i < len(tc.batchErrors) {
+					batchErr = tc.batchErrors[i]
+				}
+
+				goodsSaverMock.On("SaveGoodsBatch", mock.MatchedBy(func(goods []storage.Goods) bool {
+					return len(goods) == size
+				}), "admin").Return(nil, batchErr).Once()
+			}
+
+			handler := importer.New(slogdiscard.NewDiscardLogger(), goodsSaverMock, 0)
+
+			req, err := http.NewRequest(http.MethodPost, "/goods/import"+tc.query, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req.SetBasicAuth("admin", "secret")
+
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp importer.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.report, resp.Report)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package prices
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Currency string `json:"-" validate:"len=3,uppercase"`
+	Price    string `json:"price" validate:"required"`
+}
+
+type Response struct {
+	resp.Response
+	Prices []read.Price `json:"prices"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsPrices
+type GoodsPrices interface {
+	ListGoodsPrices(goodsID int64) ([]storage.Price, error)
+	SetGoodsPrice(goodsID int64, price storage.Price) error
+	DeleteGoodsPrice(goodsID int64, currency string) error
+}
+
+// New lists the prices of the goods in currencies other than its own.
+func New(log *slog.Logger, goodsPrices GoodsPrices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.prices.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		responsePrices(w, r, log, goodsPrices, id)
+	}
+}
+
+// NewSet sets the price of the goods in the currency URL parameter.
+func NewSet(log *slog.Logger, goodsPrices GoodsPrices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.prices.NewSet"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		req.Currency = chi.URLParam(r, "currency")
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		amount, err := money.Parse(req.Price, req.Currency)
+		if err == nil && amount < 0 {
+			err = money.ErrInvalidAmount
+		}
+		if err != nil {
+			log.Info("invalid price", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+
+		err = goodsPrices.SetGoodsPrice(id, storage.Price{Currency: req.Currency, Amount: amount})
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrBaseCurrencyPrice) {
+			log.Info("price in goods currency", slog.Int64("id", id), slog.String("currency", req.Currency))
+
+			render.JSON(w, r, resp.Error(storage.ErrBaseCurrencyPrice.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to set goods price", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to set price"))
+
+			return
+		}
+
+		log.Info("goods price set", slog.Int64("id", id), slog.String("currency", req.Currency))
+
+		responsePrices(w, r, log, goodsPrices, id)
+	}
+}
+
+// NewRemove removes the price of the goods in the currency URL parameter.
+func NewRemove(log *slog.Logger, goodsPrices GoodsPrices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.prices.NewRemove"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		currency := chi.URLParam(r, "currency")
+
+		err = goodsPrices.DeleteGoodsPrice(id, currency)
+		if errors.Is(err, storage.ErrPriceNotFound) {
+			log.Info("price not found", slog.Int64("id", id), slog.String("currency", currency))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete goods price", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("goods price deleted", slog.Int64("id", id), slog.String("currency", currency))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
+
+func responsePrices(w http.ResponseWriter, r *http.Request, log *slog.Logger, goodsPrices GoodsPrices, id int64) {
+	prices, err := goodsPrices.ListGoodsPrices(id)
+	if err != nil {
+		log.Error("failed to list goods prices", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return
+	}
+
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		Prices:   read.ToPrices(prices),
+	})
+}
@@ -0,0 +1,115 @@
+package prices_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/prices"
+	"go-api/internal/http-server/handlers/goods/prices/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestSetHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		currency  string
+		body      string
+		price     storage.Price
+		respError string
+		mockError error
+	}{
+		{
+			name:     "Success",
+			currency: "EUR",
+			body:     `{"price": "8.9"}`,
+			price:    storage.Price{Currency: "EUR", Amount: 890},
+		},
+		{
+			name:     "Zero exponent",
+			currency: "JPY",
+			body:     `{"price": "1400"}`,
+			price:    storage.Price{Currency: "JPY", Amount: 1400},
+		},
+		{
+			name:      "Too precise",
+			currency:  "JPY",
+			body:      `{"price": "14.5"}`,
+			respError: "too many decimal places for currency",
+		},
+		{
+			name:      "Negative price",
+			currency:  "EUR",
+			body:      `{"price": "-1"}`,
+			respError: "invalid amount",
+		},
+		{
+			name:      "Unknown currency",
+			currency:  "XYZ",
+			body:      `{"price": "1"}`,
+			respError: "unknown currency",
+		},
+		{
+			name:      "Invalid currency",
+			currency:  "eur",
+			body:      `{"price": "1"}`,
+			respError: "field Currency is not valid",
+		},
+		{
+			name:      "Goods currency",
+			currency:  "USD",
+			body:      `{"price": "1"}`,
+			price:     storage.Price{Currency: "USD", Amount: 100},
+			respError: storage.ErrBaseCurrencyPrice.Error(),
+			mockError: storage.ErrBaseCurrencyPrice,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsPricesMock := mocks.NewGoodsPrices(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsPricesMock.On("SetGoodsPrice", int64(1), tc.price).
+					Return(tc.mockError).Once()
+			}
+
+			if tc.respError == "" {
+				goodsPricesMock.On("ListGoodsPrices", int64(1)).
+					Return([]storage.Price{tc.price}, nil).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Put("/goods/{id}/prices/{currency}", prices.NewSet(slogdiscard.NewDiscardLogger(), goodsPricesMock))
+
+			req, err := http.NewRequest(http.MethodPut, "/goods/1/prices/"+tc.currency, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp prices.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Len(t, resp.Prices, 1)
+				require.Equal(t, tc.currency, resp.Prices[0].Currency)
+			}
+		})
+	}
+}
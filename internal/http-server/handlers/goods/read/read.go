@@ -1 +1,360 @@
 package read
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	imageRead "go-api/internal/http-server/handlers/image/read"
+	"go-api/internal/lib/api/etag"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+// Goods has the same JSON shape as goods/save.Response. Prices in other
+// currencies and the rating are only filled in when a single goods is
+// read.
+type Goods struct {
+	Id          string    `json:"id"`
+	Title       string    `json:"title"`
+	Price       string    `json:"price"`
+	Currency    string    `json:"currency"`
+	Prices      []Price   `json:"prices,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ImgUrl      string    `json:"imgUrl"`
+	ImageId     string    `json:"imageId,omitempty"`
+	Weight      int32     `json:"weight"`
+	Variants    []Variant `json:"variants,omitempty"`
+	Rating      *Rating   `json:"rating,omitempty"`
+}
+
+type Price struct {
+	Currency string `json:"currency"`
+	Price    string `json:"price"`
+}
+
+// Variant carries the effective price and weight of the variant, those
+// of the goods unless overridden.
+type Variant struct {
+	Sku     string            `json:"sku"`
+	Options map[string]string `json:"options"`
+	Price   string            `json:"price"`
+	Weight  int32             `json:"weight"`
+	Stock   int64             `json:"stock"`
+}
+
+// Rating aggregates the approved reviews of the goods; Average is rounded
+// to two decimals.
+type Rating struct {
+	Average float64 `json:"average"`
+	Count   int64   `json:"count"`
+}
+
+type Response struct {
+	resp.Response
+	Goods
+}
+
+type ListRequest struct {
+	Limit    int    `validate:"min=0,max=100"`
+	Offset   int    `validate:"min=0"`
+	After    int64  `validate:"min=0"`
+	Sort     string `validate:"omitempty,oneof=id price title"`
+	Order    string `validate:"omitempty,oneof=asc desc"`
+	Category int64  `validate:"min=0"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Goods []Goods `json:"goods"`
+	Next  string  `json:"next,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsGetter
+type GoodsGetter interface {
+	GetGoods(id int64) (storage.Goods, error)
+	ListGoods(opts storage.GoodsListOptions) ([]storage.Goods, error)
+	ListGoodsPrices(goodsID int64) ([]storage.Price, error)
+	ListVariants(goodsID int64) ([]storage.Variant, error)
+	ListVariantsByGoods(goodsIDs []int64) (map[int64][]storage.Variant, error)
+	GetGoodsRating(goodsID int64) (storage.Rating, error)
+}
+
+func New(log *slog.Logger, goodsGetter GoodsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		goods, err := goodsGetter.GetGoods(id)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		prices, err := goodsGetter.ListGoodsPrices(id)
+		if err != nil {
+			log.Error("failed to list goods prices", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		variants, err := goodsGetter.ListVariants(id)
+		if err != nil {
+			log.Error("failed to list goods variants", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		rating, err := goodsGetter.GetGoodsRating(id)
+		if err != nil {
+			log.Error("failed to get goods rating", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got goods", slog.Int64("id", id))
+
+		w.Header().Set("ETag", etag.Format(goods.Version))
+
+		res := Response{
+			Response: resp.OK(),
+			Goods:    ToGoods(goods),
+		}
+		res.Prices = ToPrices(prices)
+		res.Variants = ToVariants(goods, variants)
+		res.Rating = ToRating(rating)
+
+		render.JSON(w, r, res)
+	}
+}
+
+func NewList(log *slog.Logger, goodsGetter GoodsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.read.NewList"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		req, err := parseListRequest(r)
+		if err != nil {
+			log.Info("failed to parse query", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultLimit
+		}
+
+		goods, err := goodsGetter.ListGoods(storage.GoodsListOptions{
+			Limit:      limit,
+			Offset:     req.Offset,
+			AfterID:    req.After,
+			SortBy:     req.Sort,
+			Desc:       req.Order == "desc",
+			CategoryID: req.Category,
+		})
+		if err != nil {
+			log.Error("failed to list goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		ids := make([]int64, 0, len(goods))
+		for _, g := range goods {
+			ids = append(ids, g.ID)
+		}
+
+		variants, err := goodsGetter.ListVariantsByGoods(ids)
+		if err != nil {
+			log.Error("failed to list goods variants", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed goods", slog.Int("count", len(goods)))
+
+		res := ListResponse{
+			Response: resp.OK(),
+			Goods:    make([]Goods, 0, len(goods)),
+		}
+
+		for _, g := range goods {
+			item := ToGoods(g)
+			item.Variants = ToVariants(g, variants[g.ID])
+
+			res.Goods = append(res.Goods, item)
+		}
+
+		// a full page means there may be more rows after the last one
+		if len(goods) == limit {
+			res.Next = strconv.FormatInt(goods[len(goods)-1].ID, 10)
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parseListRequest(r *http.Request) (ListRequest, error) {
+	var (
+		req ListRequest
+		err error
+	)
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("after"); v != "" {
+		if req.After, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("category"); v != "" {
+		if req.Category, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return req, err
+		}
+	}
+
+	req.Sort = q.Get("sort")
+	req.Order = q.Get("order")
+
+	return req, nil
+}
+
+// ToGoods converts storage.Goods to its JSON representation. Goods with
+// an uploaded image and no external URL point imgUrl to the image.
+func ToGoods(g storage.Goods) Goods {
+	goods := Goods{
+		Id:          strconv.FormatInt(g.ID, 10),
+		Title:       g.Title,
+		Price:       money.Format(g.Price, g.Currency),
+		Currency:    g.Currency,
+		Description: g.Description,
+		ImgUrl:      g.ImgUrl,
+		Weight:      g.Weight,
+	}
+
+	if g.ImageID != 0 {
+		goods.ImageId = strconv.FormatInt(g.ImageID, 10)
+
+		if goods.ImgUrl == "" {
+			goods.ImgUrl = imageRead.URL(g.ImageID)
+		}
+	}
+
+	return goods
+}
+
+// ToPrices converts a price list to its JSON representation.
+func ToPrices(prices []storage.Price) []Price {
+	res := make([]Price, 0, len(prices))
+
+	for _, p := range prices {
+		res = append(res, Price{
+			Currency: p.Currency,
+			Price:    money.Format(p.Amount, p.Currency),
+		})
+	}
+
+	return res
+}
+
+// ToRating converts the rating of goods to its JSON representation.
+func ToRating(rating storage.Rating) *Rating {
+	return &Rating{
+		Average: math.Round(rating.Average*100) / 100,
+		Count:   rating.Count,
+	}
+}
+
+// ToVariants converts the variants of goods to their JSON representation.
+func ToVariants(goods storage.Goods, variants []storage.Variant) []Variant {
+	res := make([]Variant, 0, len(variants))
+
+	for _, v := range variants {
+		res = append(res, ToVariant(goods, v))
+	}
+
+	return res
+}
+
+func ToVariant(goods storage.Goods, v storage.Variant) Variant {
+	return Variant{
+		Sku:     v.SKU,
+		Options: v.Options,
+		Price:   money.Format(v.EffectivePrice(goods), goods.Currency),
+		Weight:  v.EffectiveWeight(goods),
+		Stock:   v.Stock,
+	}
+}
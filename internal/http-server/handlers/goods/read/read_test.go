@@ -0,0 +1,232 @@
+package read_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	"go-api/internal/http-server/handlers/goods/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestReadHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		id        string
+		goods     storage.Goods
+		prices    []storage.Price
+		variants  []storage.Variant
+		respError string
+		mockError error
+	}{
+		{
+			name:   "Success",
+			id:     "1",
+			goods:  storage.Goods{ID: 1, Title: "Tea", Price: 950, Currency: "USD", ImgUrl: "https://example.com/tea.png", Weight: 100},
+			prices: []storage.Price{{Currency: "JPY", Amount: 1400}},
+			variants: []storage.Variant{
+				{SKU: "TEA-S", Options: map[string]string{"size": "S"}, Stock: 3},
+				{SKU: "TEA-L", Options: map[string]string{"size": "L"}, Price: ptr(int64(1200)), Weight: ptr(int32(250))},
+			},
+		},
+		{
+			name:      "Invalid id",
+			id:        "abc",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not found",
+			id:        "2",
+			respError: "not found",
+			mockError: storage.ErrGoodsNotFound,
+		},
+		{
+			name:      "GetGoods Error",
+			id:        "3",
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsGetterMock := mocks.NewGoodsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsGetterMock.On("GetGoods", mock.AnythingOfType("int64")).
+					Return(tc.goods, tc.mockError).Once()
+			}
+			if tc.respError == "" {
+				goodsGetterMock.On("ListGoodsPrices", tc.goods.ID).
+					Return(tc.prices, nil).Once()
+				goodsGetterMock.On("ListVariants", tc.goods.ID).
+					Return(tc.variants, nil).Once()
+				goodsGetterMock.On("GetGoodsRating", tc.goods.ID).
+					Return(storage.Rating{Average: 13.0 / 3, Count: 3}, nil).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Get("/goods/{id}", read.New(slogdiscard.NewDiscardLogger(), goodsGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, "/goods/"+tc.id, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.id, resp.Id)
+				require.Equal(t, tc.goods.Title, resp.Title)
+				require.Equal(t, "9.50", resp.Price)
+				require.Equal(t, "USD", resp.Currency)
+				require.Equal(t, []read.Price{{Currency: "JPY", Price: "1400"}}, resp.Prices)
+				require.Equal(t, []read.Variant{
+					{Sku: "TEA-S", Options: map[string]string{"size": "S"}, Price: "9.50", Weight: 100, Stock: 3},
+					{Sku: "TEA-L", Options: map[string]string{"size": "L"}, Price: "12.00", Weight: 250},
+				}, resp.Variants)
+				require.Equal(t, &read.Rating{Average: 4.33, Count: 3}, resp.Rating)
+			}
+		})
+	}
+}
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		opts      storage.GoodsListOptions
+		goods     []storage.Goods
+		next      string
+		respError string
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			opts:  storage.GoodsListOptions{Limit: 20},
+			goods: []storage.Goods{{ID: 1}, {ID: 2}},
+		},
+		{
+			name:  "Cursor page",
+			query: "?limit=2&after=5&sort=price&order=desc",
+			opts:  storage.GoodsListOptions{Limit: 2, AfterID: 5, SortBy: "price", Desc: true},
+			goods: []storage.Goods{{ID: 4}, {ID: 3}},
+			next:  "3",
+		},
+		{
+			name:  "Offset page",
+			query: "?limit=10&offset=30&sort=title",
+			opts:  storage.GoodsListOptions{Limit: 10, Offset: 30, SortBy: "title"},
+			goods: []storage.Goods{},
+		},
+		{
+			name:  "Category",
+			query: "?category=7",
+			opts:  storage.GoodsListOptions{Limit: 20, CategoryID: 7},
+			goods: []storage.Goods{{ID: 9}},
+		},
+		{
+			name:      "Invalid sort",
+			query:     "?sort=weight",
+			respError: "field Sort is not valid",
+		},
+		{
+			name:      "Limit too big",
+			query:     "?limit=1000",
+			respError: "field Limit is not valid",
+		},
+		{
+			name:      "Invalid limit",
+			query:     "?limit=ten",
+			respError: "invalid request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsGetterMock := mocks.NewGoodsGetter(t)
+
+			if tc.respError == "" {
+				goodsGetterMock.On("ListGoods", tc.opts).
+					Return(tc.goods, nil).Once()
+				goodsGetterMock.On("ListVariantsByGoods", mock.AnythingOfType("[]int64")).
+					Return(map[int64][]storage.Variant{}, nil).Once()
+			}
+
+			handler := read.NewList(slogdiscard.NewDiscardLogger(), goodsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/goods"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.ListResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.next, resp.Next)
+			require.Len(t, resp.Goods, len(tc.goods))
+		})
+	}
+}
+
+func TestListEmbedsVariants(t *testing.T) {
+	goodsGetterMock := mocks.NewGoodsGetter(t)
+
+	goodsGetterMock.On("ListGoods", storage.GoodsListOptions{Limit: 20}).
+		Return([]storage.Goods{
+			{ID: 1, Price: 500, Currency: "USD", Weight: 200},
+			{ID: 2, Price: 700, Currency: "USD"},
+		}, nil).Once()
+	goodsGetterMock.On("ListVariantsByGoods", []int64{1, 2}).
+		Return(map[int64][]storage.Variant{
+			1: {{GoodsID: 1, SKU: "SHIRT-M", Options: map[string]string{"size": "M"}, Price: ptr(int64(550))}},
+		}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, "/goods", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	read.NewList(slogdiscard.NewDiscardLogger(), goodsGetterMock).ServeHTTP(rr, req)
+
+	var resp read.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+	require.Len(t, resp.Goods, 2)
+	require.Equal(t, []read.Variant{
+		{Sku: "SHIRT-M", Options: map[string]string{"size": "M"}, Price: "5.50", Weight: 200},
+	}, resp.Goods[0].Variants)
+	require.Empty(t, resp.Goods[1].Variants)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -1 +1,63 @@
 package remove
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsRemover
+type GoodsRemover interface {
+	DeleteGoods(id int64, actor string) error
+}
+
+func New(log *slog.Logger, goodsRemover GoodsRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.remove.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		actor, _, _ := r.BasicAuth()
+
+		err = goodsRemover.DeleteGoods(id, actor)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to remove goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("goods removed", slog.Int64("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,84 @@
+package remove_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/remove"
+	"go-api/internal/http-server/handlers/goods/remove/mocks"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestRemoveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		id        string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			id:   "1",
+		},
+		{
+			name:      "Invalid id",
+			id:        "first",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not found",
+			id:        "2",
+			respError: "not found",
+			mockError: storage.ErrGoodsNotFound,
+		},
+		{
+			name:      "DeleteGoods Error",
+			id:        "3",
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsRemoverMock := mocks.NewGoodsRemover(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsRemoverMock.On("DeleteGoods", mock.AnythingOfType("int64"), "admin").
+					Return(tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Delete("/goods/{id}", remove.New(slogdiscard.NewDiscardLogger(), goodsRemoverMock))
+
+			req, err := http.NewRequest(http.MethodDelete, "/goods/"+tc.id, nil)
+			require.NoError(t, err)
+
+			req.SetBasicAuth("admin", "secret")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var res resp.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+			require.Equal(t, tc.respError, res.Error)
+		})
+	}
+}
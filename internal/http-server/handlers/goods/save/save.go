@@ -1,36 +1,55 @@
 package save
 
 import (
+	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	imageRead "go-api/internal/http-server/handlers/image/read"
 	resp "go-api/internal/lib/api/response"
 	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
 	"log/slog"
 	"net/http"
 	"strconv"
 )
 
+// DefaultCurrency is the currency of goods saved without one, as they all
+// were before goods had a currency.
+const DefaultCurrency = "USD"
+
 type Request struct {
 	Title       string `json:"title" validate:"required"`
 	Price       string `json:"price" validate:"required"`
+	Currency    string `json:"currency" validate:"omitempty,len=3,uppercase"`
 	Description string `json:"description,omitempty"`
-	ImgUrl      string `json:"imgUrl" validate:"required,url"`
+	ImgUrl      string `json:"imgUrl" validate:"required_without=ImageId,omitempty,url"`
+	ImageId     string `json:"imageId,omitempty" validate:"omitempty,number"`
 	Weight      string `json:"weight" validate:"required"`
 }
 
 type Response struct {
 	resp.Response
-	Id          string  `json:"id"`
-	Title       string  `json:"title"`
-	Price       float64 `json:"price"`
-	Description string  `json:"description,omitempty"`
-	ImgUrl      string  `json:"imgUrl"`
-	Weight      int32   `json:"weight"`
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Price       string `json:"price"`
+	Currency    string `json:"currency"`
+	Description string `json:"description,omitempty"`
+	ImgUrl      string `json:"imgUrl"`
+	ImageId     string `json:"imageId,omitempty"`
+	Weight      int32  `json:"weight"`
 }
 
+var (
+	ErrInvalidPrice  = errors.New("failed parse price value")
+	ErrInvalidWeight = errors.New("failed parse weight value")
+	ErrInvalidImage  = errors.New("failed parse image id")
+)
+
 type GoodsSaver interface {
-	SaveGoods(title string, price float64, description string, imgUrl string, weight int32) (int64, error)
+	SaveGoods(goods storage.Goods, actor string) (int64, error)
 }
 
 func New(log *slog.Logger, goodsSaver GoodsSaver) http.HandlerFunc {
@@ -55,37 +74,26 @@ func New(log *slog.Logger, goodsSaver GoodsSaver) http.HandlerFunc {
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
-			validateErr := err.(validator.ValidationErrors)
-
+		goods, err := Validate(req)
+		if err != nil {
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.JSON(w, r, ValidationResponse(err))
 
 			return
 		}
 
-		priceFloat64, err := strconv.ParseFloat(req.Price, 64)
-		if err != nil {
-			log.Error("failed parse price value", sl.Err(err))
-
-			render.JSON(w, r, resp.Error("failed parse price value"))
-
-			return
-		}
+		// the basic auth user is recorded in the goods history
+		actor, _, _ := r.BasicAuth()
 
-		weight, err := strconv.ParseInt(req.Weight, 10, 32)
-		if err != nil {
-			log.Error("failed parse weight value", sl.Err(err))
+		id, err := goodsSaver.SaveGoods(goods, actor)
+		if errors.Is(err, storage.ErrImageNotFound) {
+			log.Info("image not found", slog.Int64("image_id", goods.ImageID))
 
-			render.JSON(w, r, resp.Error("failed parse weight value"))
+			render.JSON(w, r, resp.Error("image not found"))
 
 			return
 		}
-
-		i32Weight := int32(weight)
-
-		id, err := goodsSaver.SaveGoods(req.Title, priceFloat64, req.Description, req.ImgUrl, i32Weight)
 		if err != nil {
 			log.Error("failed to add goods", sl.Err(err))
 
@@ -96,20 +104,99 @@ func New(log *slog.Logger, goodsSaver GoodsSaver) http.HandlerFunc {
 
 		log.Info("goods added", slog.Int64("id", id))
 
-		idString := strconv.FormatInt(id, 10)
+		goods.ID = id
 
-		responseOK(w, r, idString, req.Title, priceFloat64, req.Description, req.ImgUrl, i32Weight)
+		render.JSON(w, r, ToResponse(goods))
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, id string, title string, price float64, description string, imgUrl string, weight int32) {
-	render.JSON(w, r, Response{
-		Response:    resp.OK(),
-		Id:          id,
-		Title:       title,
+// Validate checks req with the rules applied to every goods write and
+// converts it to storage.Goods, in DefaultCurrency if req has none.
+// Besides validator.ValidationErrors it may return ErrInvalidPrice
+// wrapping a money error, ErrInvalidWeight or ErrInvalidImage.
+func Validate(req Request) (storage.Goods, error) {
+	if err := validator.New().Struct(req); err != nil {
+		return storage.Goods{}, err
+	}
+
+	if req.Currency == "" {
+		req.Currency = DefaultCurrency
+	}
+
+	price, err := money.Parse(req.Price, req.Currency)
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%w: %w", ErrInvalidPrice, err)
+	}
+	if price < 0 {
+		return storage.Goods{}, fmt.Errorf("%w: negative price", ErrInvalidPrice)
+	}
+
+	weight, err := strconv.ParseInt(req.Weight, 10, 32)
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%w: %w", ErrInvalidWeight, err)
+	}
+
+	var imageID int64
+	if req.ImageId != "" {
+		imageID, err = strconv.ParseInt(req.ImageId, 10, 64)
+		if err != nil {
+			return storage.Goods{}, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+		}
+	}
+
+	return storage.Goods{
+		Title:       req.Title,
 		Price:       price,
-		Description: description,
-		ImgUrl:      imgUrl,
-		Weight:      weight,
-	})
+		Currency:    req.Currency,
+		Description: req.Description,
+		ImgUrl:      req.ImgUrl,
+		ImageID:     imageID,
+		Weight:      int32(weight),
+	}, nil
+}
+
+// ValidationResponse converts an error returned by Validate to a response.
+func ValidationResponse(err error) resp.Response {
+	var validateErr validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &validateErr):
+		return resp.ValidationError(validateErr)
+	case errors.Is(err, money.ErrUnknownCurrency):
+		return resp.Error(money.ErrUnknownCurrency.Error())
+	case errors.Is(err, money.ErrTooPrecise):
+		return resp.Error(money.ErrTooPrecise.Error())
+	case errors.Is(err, ErrInvalidPrice):
+		return resp.Error(ErrInvalidPrice.Error())
+	case errors.Is(err, ErrInvalidWeight):
+		return resp.Error(ErrInvalidWeight.Error())
+	case errors.Is(err, ErrInvalidImage):
+		return resp.Error(ErrInvalidImage.Error())
+	default:
+		return resp.Error("invalid request")
+	}
+}
+
+// ToResponse converts saved goods to the response of every goods write.
+func ToResponse(goods storage.Goods) Response {
+	res := Response{
+		Response:    resp.OK(),
+		Id:          strconv.FormatInt(goods.ID, 10),
+		Title:       goods.Title,
+		Price:       money.Format(goods.Price, goods.Currency),
+		Currency:    goods.Currency,
+		Description: goods.Description,
+		ImgUrl:      goods.ImgUrl,
+		Weight:      goods.Weight,
+	}
+
+	if goods.ImageID != 0 {
+		res.ImageId = strconv.FormatInt(goods.ImageID, 10)
+
+		if res.ImgUrl == "" {
+			res.ImgUrl = imageRead.URL(goods.ImageID)
+		}
+	}
+
+	return res
 }
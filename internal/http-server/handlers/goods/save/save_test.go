@@ -0,0 +1,80 @@
+package save_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/save"
+	"go-api/internal/lib/money"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		currency string
+		price    int64
+		err      error
+	}{
+		{
+			name:     "Currency",
+			currency: "EUR",
+			price:    1250,
+		},
+		{
+			name:     "Without currency",
+			currency: "",
+			price:    1250,
+		},
+		{
+			name:     "Unknown currency",
+			currency: "ABC",
+			err:      money.ErrUnknownCurrency,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goods, err := save.Validate(save.Request{
+				Title:    "Apple",
+				Price:    "12.50",
+				Currency: tc.currency,
+				ImgUrl:   "https://example.com/apple.png",
+				Weight:   "150",
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			want := tc.currency
+			if want == "" {
+				want = save.DefaultCurrency
+			}
+
+			require.Equal(t, want, goods.Currency)
+			require.Equal(t, tc.price, goods.Price)
+		})
+	}
+}
+
+func TestValidateLowercaseCurrency(t *testing.T) {
+	_, err := save.Validate(save.Request{
+		Title:    "Apple",
+		Price:    "12.50",
+		Currency: "usd",
+		ImgUrl:   "https://example.com/apple.png",
+		Weight:   "150",
+	})
+
+	var validationErrs validator.ValidationErrors
+	require.True(t, errors.As(err, &validationErrs))
+}
@@ -0,0 +1,182 @@
+package search
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+type Request struct {
+	Query     string `validate:"required"`
+	Currency  string `validate:"omitempty,len=3,uppercase"`
+	MinPrice  *int64 `validate:"omitempty,min=0"`
+	MaxPrice  *int64 `validate:"omitempty,min=0"`
+	MinWeight *int32 `validate:"omitempty,min=0"`
+	MaxWeight *int32 `validate:"omitempty,min=0"`
+	Limit     int    `validate:"min=0,max=100"`
+	Offset    int    `validate:"min=0"`
+}
+
+type Result struct {
+	read.Goods
+	Highlight string  `json:"highlight"`
+	Snippet   string  `json:"snippet,omitempty"`
+	Rank      float64 `json:"rank"`
+}
+
+type Response struct {
+	resp.Response
+	Results []Result `json:"results"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsSearcher
+type GoodsSearcher interface {
+	SearchGoods(opts storage.GoodsSearchOptions) ([]storage.GoodsSearchResult, error)
+}
+
+func New(log *slog.Logger, goodsSearcher GoodsSearcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.search.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		req, err := parseRequest(r)
+		if err != nil {
+			log.Info("failed to parse query", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultLimit
+		}
+
+		results, err := goodsSearcher.SearchGoods(storage.GoodsSearchOptions{
+			Query:     req.Query,
+			Currency:  req.Currency,
+			MinPrice:  req.MinPrice,
+			MaxPrice:  req.MaxPrice,
+			MinWeight: req.MinWeight,
+			MaxWeight: req.MaxWeight,
+			Limit:     limit,
+			Offset:    req.Offset,
+		})
+		if err != nil {
+			log.Error("failed to search goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("goods found", slog.String("query", req.Query), slog.Int("count", len(results)))
+
+		res := Response{
+			Response: resp.OK(),
+			Results:  make([]Result, 0, len(results)),
+		}
+
+		for _, result := range results {
+			res.Results = append(res.Results, Result{
+				Goods:     read.ToGoods(result.Goods),
+				Highlight: result.Highlight,
+				Snippet:   result.Snippet,
+				Rank:      result.Rank,
+			})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parseRequest(r *http.Request) (Request, error) {
+	var err error
+
+	q := r.URL.Query()
+
+	req := Request{Query: q.Get("q"), Currency: q.Get("currency")}
+
+	// prices are decimal strings in the currency, which is required with them
+	if req.MinPrice, err = parsePrice(q.Get("min_price"), req.Currency); err != nil {
+		return req, err
+	}
+	if req.MaxPrice, err = parsePrice(q.Get("max_price"), req.Currency); err != nil {
+		return req, err
+	}
+	if req.MinWeight, err = parseInt32(q.Get("min_weight")); err != nil {
+		return req, err
+	}
+	if req.MaxWeight, err = parseInt32(q.Get("max_weight")); err != nil {
+		return req, err
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	return req, nil
+}
+
+func parsePrice(v string, currency string) (*int64, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	minor, err := money.Parse(v, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &minor, nil
+}
+
+func parseInt32(v string) (*int32, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	i := int32(n)
+
+	return &i, nil
+}
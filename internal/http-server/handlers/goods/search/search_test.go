@@ -0,0 +1,121 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/search"
+	"go-api/internal/http-server/handlers/goods/search/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestSearchHandler(t *testing.T) {
+	minPrice, maxPrice := int64(1000), int64(2050)
+	maxWeight := int32(500)
+
+	cases := []struct {
+		name      string
+		query     string
+		opts      storage.GoodsSearchOptions
+		results   []storage.GoodsSearchResult
+		respError string
+		mockError error
+	}{
+		{
+			name:  "Success",
+			query: "?q=green+tea",
+			opts:  storage.GoodsSearchOptions{Query: "green tea", Limit: 20},
+			results: []storage.GoodsSearchResult{
+				{Goods: storage.Goods{ID: 1, Title: "Green tea"}, Highlight: "<mark>Green</mark> <mark>tea</mark>"},
+			},
+		},
+		{
+			name:  "Ranges",
+			query: "?q=tea&currency=USD&min_price=10&max_price=20.5&max_weight=500&limit=5&offset=10",
+			opts: storage.GoodsSearchOptions{
+				Query:     "tea",
+				Currency:  "USD",
+				MinPrice:  &minPrice,
+				MaxPrice:  &maxPrice,
+				MaxWeight: &maxWeight,
+				Limit:     5,
+				Offset:    10,
+			},
+			results: []storage.GoodsSearchResult{},
+		},
+		{
+			name:      "Empty query",
+			query:     "?currency=USD&min_price=1",
+			respError: "field Query is a required field",
+		},
+		{
+			name:      "Negative price",
+			query:     "?q=tea&currency=USD&min_price=-1",
+			respError: "field MinPrice is not valid",
+		},
+		{
+			name:      "Price without currency",
+			query:     "?q=tea&min_price=1",
+			respError: "invalid request",
+		},
+		{
+			name:      "Price too precise",
+			query:     "?q=tea&currency=JPY&max_price=1.5",
+			respError: "invalid request",
+		},
+		{
+			name:      "Invalid weight",
+			query:     "?q=tea&max_weight=heavy",
+			respError: "invalid request",
+		},
+		{
+			name:      "SearchGoods Error",
+			query:     "?q=tea",
+			opts:      storage.GoodsSearchOptions{Query: "tea", Limit: 20},
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsSearcherMock := mocks.NewGoodsSearcher(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsSearcherMock.On("SearchGoods", tc.opts).
+					Return(tc.results, tc.mockError).Once()
+			}
+
+			handler := search.New(slogdiscard.NewDiscardLogger(), goodsSearcherMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/goods/search"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp search.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			require.Len(t, resp.Results, len(tc.results))
+
+			for i, result := range tc.results {
+				require.Equal(t, result.Highlight, resp.Results[i].Highlight)
+			}
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package stock
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+type MoveRequest struct {
+	Operation string `json:"-" validate:"oneof=receive adjust reserve release commit"`
+	Quantity  int64  `json:"quantity" validate:"required"`
+	Reference string `json:"reference,omitempty" validate:"max=255"`
+}
+
+type ThresholdRequest struct {
+	Threshold int64 `json:"threshold" validate:"min=0"`
+}
+
+type Stock struct {
+	GoodsId           string `json:"goodsId"`
+	OnHand            int64  `json:"onHand"`
+	Reserved          int64  `json:"reserved"`
+	Available         int64  `json:"available"`
+	LowStockThreshold int64  `json:"lowStockThreshold"`
+	LowStock          bool   `json:"lowStock"`
+}
+
+type Response struct {
+	resp.Response
+	Stock
+}
+
+type Movement struct {
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Quantity  int64     `json:"quantity"`
+	OnHand    int64     `json:"onHand"`
+	Reserved  int64     `json:"reserved"`
+	Reference string    `json:"reference,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type MovementsResponse struct {
+	resp.Response
+	Movements []Movement `json:"movements"`
+}
+
+type LowStockItem struct {
+	read.Goods
+	Stock Stock `json:"stock"`
+}
+
+type LowStockResponse struct {
+	resp.Response
+	Goods []LowStockItem `json:"goods"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=StockKeeper
+type StockKeeper interface {
+	GetStock(goodsID int64) (storage.Stock, error)
+	MoveStock(goodsID int64, kind string, quantity int64, reference string) (storage.Stock, error)
+	SetLowStockThreshold(goodsID int64, threshold int64) error
+	ListStockMovements(goodsID int64, limit int, offset int) ([]storage.StockMovement, error)
+	ListLowStock(limit int, offset int) ([]storage.LowStockItem, error)
+}
+
+// New returns the stock level of the goods.
+func New(log *slog.Logger, stockKeeper StockKeeper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.stock.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		stock, err := stockKeeper.GetStock(id)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get stock", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got stock", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Stock:    toStock(stock),
+		})
+	}
+}
+
+// NewMove receives, adjusts, reserves, releases or commits stock
+// depending on the operation URL parameter.
+func NewMove(log *slog.Logger, stockKeeper StockKeeper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.stock.NewMove"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req MoveRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		req.Operation = chi.URLParam(r, "operation")
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		stock, err := stockKeeper.MoveStock(id, req.Operation, req.Quantity, req.Reference)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInsufficientStock) || errors.Is(err, storage.ErrInvalidStockMovement) {
+			log.Info("stock movement rejected", slog.Int64("id", id), sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to move stock", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("stock moved", slog.Int64("id", id), slog.String("operation", req.Operation))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Stock:    toStock(stock),
+		})
+	}
+}
+
+// NewThreshold sets the available stock level at which the goods shows
+// up in the low-stock report; zero disables reporting.
+func NewThreshold(log *slog.Logger, stockKeeper StockKeeper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.stock.NewThreshold"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req ThresholdRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		err = stockKeeper.SetLowStockThreshold(id, req.Threshold)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to set low stock threshold", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("low stock threshold set", slog.Int64("id", id), slog.Int64("threshold", req.Threshold))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
+
+// NewMovements returns the stock ledger of the goods, newest first.
+func NewMovements(log *slog.Logger, stockKeeper StockKeeper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.stock.NewMovements"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		movements, err := stockKeeper.ListStockMovements(id, limit, offset)
+		if err != nil {
+			log.Error("failed to list stock movements", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed stock movements", slog.Int64("id", id), slog.Int("count", len(movements)))
+
+		res := MovementsResponse{
+			Response:  resp.OK(),
+			Movements: make([]Movement, 0, len(movements)),
+		}
+
+		for _, m := range movements {
+			res.Movements = append(res.Movements, Movement{
+				Id:        strconv.FormatInt(m.ID, 10),
+				Kind:      m.Kind,
+				Quantity:  m.Quantity,
+				OnHand:    m.OnHand,
+				Reserved:  m.Reserved,
+				Reference: m.Reference,
+				CreatedAt: m.CreatedAt,
+			})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// NewLowStockReport lists goods whose available stock has fallen to
+// their low-stock threshold.
+func NewLowStockReport(log *slog.Logger, stockKeeper StockKeeper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.stock.NewLowStockReport"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		items, err := stockKeeper.ListLowStock(limit, offset)
+		if err != nil {
+			log.Error("failed to list low stock", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed low stock", slog.Int("count", len(items)))
+
+		res := LowStockResponse{
+			Response: resp.OK(),
+			Goods:    make([]LowStockItem, 0, len(items)),
+		}
+
+		for _, item := range items {
+			res.Goods = append(res.Goods, LowStockItem{
+				Goods: read.ToGoods(item.Goods),
+				Stock: toStock(item.Stock),
+			})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
+
+func toStock(s storage.Stock) Stock {
+	return Stock{
+		GoodsId:           strconv.FormatInt(s.GoodsID, 10),
+		OnHand:            s.OnHand,
+		Reserved:          s.Reserved,
+		Available:         s.Available(),
+		LowStockThreshold: s.LowStockThreshold,
+		LowStock:          s.LowStockThreshold > 0 && s.Available() <= s.LowStockThreshold,
+	}
+}
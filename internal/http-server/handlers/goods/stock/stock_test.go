@@ -0,0 +1,113 @@
+package stock_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/stock"
+	"go-api/internal/http-server/handlers/goods/stock/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestMoveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		operation string
+		body      string
+		quantity  int64
+		reference string
+		stock     storage.Stock
+		want      stock.Stock
+		respError string
+		mockError error
+	}{
+		{
+			name:      "Reserve",
+			operation: "reserve",
+			body:      `{"quantity": 3, "reference": "order-1"}`,
+			quantity:  3,
+			reference: "order-1",
+			stock:     storage.Stock{GoodsID: 1, OnHand: 10, Reserved: 3, LowStockThreshold: 7},
+			want:      stock.Stock{GoodsId: "1", OnHand: 10, Reserved: 3, Available: 7, LowStockThreshold: 7, LowStock: true},
+		},
+		{
+			name:      "Negative adjust",
+			operation: "adjust",
+			body:      `{"quantity": -2}`,
+			quantity:  -2,
+			stock:     storage.Stock{GoodsID: 1, OnHand: 8},
+			want:      stock.Stock{GoodsId: "1", OnHand: 8, Available: 8},
+		},
+		{
+			name:      "Unknown operation",
+			operation: "steal",
+			body:      `{"quantity": 1}`,
+			respError: "field Operation is not valid",
+		},
+		{
+			name:      "Zero quantity",
+			operation: "receive",
+			body:      `{"quantity": 0}`,
+			respError: "field Quantity is a required field",
+		},
+		{
+			name:      "Insufficient stock",
+			operation: "reserve",
+			body:      `{"quantity": 100}`,
+			quantity:  100,
+			respError: "insufficient stock",
+			mockError: storage.ErrInsufficientStock,
+		},
+		{
+			name:      "Unknown goods",
+			operation: "receive",
+			body:      `{"quantity": 1}`,
+			quantity:  1,
+			respError: "not found",
+			mockError: storage.ErrGoodsNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stockKeeperMock := mocks.NewStockKeeper(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				stockKeeperMock.On("MoveStock", int64(1), tc.operation, tc.quantity, tc.reference).
+					Return(tc.stock, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/goods/{id}/stock/{operation}", stock.NewMove(slogdiscard.NewDiscardLogger(), stockKeeperMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/goods/1/stock/"+tc.operation, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp stock.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.want, resp.Stock)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package trash
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	"go-api/internal/lib/api/etag"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+type Goods struct {
+	read.Goods
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Goods []Goods `json:"goods"`
+}
+
+type PurgeResponse struct {
+	resp.Response
+	Purged int64 `json:"purged"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsTrash
+type GoodsTrash interface {
+	ListDeletedGoods(limit int, offset int) ([]storage.Goods, error)
+	UndeleteGoods(id int64, actor string) (storage.Goods, error)
+	PurgeGoods(before time.Time) (int64, error)
+}
+
+// New lists the goods in the trash, most recently deleted first.
+func New(log *slog.Logger, goodsTrash GoodsTrash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.trash.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		goods, err := goodsTrash.ListDeletedGoods(limit, offset)
+		if err != nil {
+			log.Error("failed to list deleted goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed deleted goods", slog.Int("count", len(goods)))
+
+		res := ListResponse{
+			Response: resp.OK(),
+			Goods:    make([]Goods, 0, len(goods)),
+		}
+
+		for _, g := range goods {
+			res.Goods = append(res.Goods, Goods{Goods: read.ToGoods(g), DeletedAt: g.DeletedAt})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// NewRestore takes the goods with the id URL parameter out of the trash.
+func NewRestore(log *slog.Logger, goodsTrash GoodsTrash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.trash.NewRestore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		actor, _, _ := r.BasicAuth()
+
+		goods, err := goodsTrash.UndeleteGoods(id, actor)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not in trash", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("goods restored", slog.Int64("id", id))
+
+		w.Header().Set("ETag", etag.Format(goods.Version))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Goods:    read.ToGoods(goods),
+		})
+	}
+}
+
+// NewPurge permanently deletes the goods that have been in the trash for
+// longer than the olderThan query parameter, a duration such as 720h.
+func NewPurge(log *slog.Logger, goodsTrash GoodsTrash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.trash.NewPurge"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		olderThan, err := time.ParseDuration(r.URL.Query().Get("olderThan"))
+		if err != nil || olderThan < 0 {
+			log.Info("invalid olderThan", slog.String("olderThan", r.URL.Query().Get("olderThan")))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		purged, err := goodsTrash.PurgeGoods(time.Now().Add(-olderThan))
+		if err != nil {
+			log.Error("failed to purge goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("goods purged", slog.Int64("count", purged))
+
+		render.JSON(w, r, PurgeResponse{
+			Response: resp.OK(),
+			Purged:   purged,
+		})
+	}
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
@@ -0,0 +1,127 @@
+package trash_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	"go-api/internal/http-server/handlers/goods/trash"
+	"go-api/internal/http-server/handlers/goods/trash/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestListHandler(t *testing.T) {
+	goodsTrashMock := mocks.NewGoodsTrash(t)
+
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	goodsTrashMock.On("ListDeletedGoods", 5, 10).
+		Return([]storage.Goods{{ID: 3, Title: "Tea", Price: 999, Currency: "USD", DeletedAt: deletedAt}}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, "/goods/trash?limit=5&offset=10", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	trash.New(slogdiscard.NewDiscardLogger(), goodsTrashMock).ServeHTTP(rr, req)
+
+	var res trash.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Empty(t, res.Error)
+	require.Len(t, res.Goods, 1)
+	require.Equal(t, "3", res.Goods[0].Id)
+	require.Equal(t, "9.99", res.Goods[0].Price)
+	require.Equal(t, deletedAt, res.Goods[0].DeletedAt)
+}
+
+func TestRestoreHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		id        string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			id:   "3",
+		},
+		{
+			name:      "Invalid id",
+			id:        "abc",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not in trash",
+			id:        "3",
+			respError: "not found",
+			mockError: storage.ErrGoodsNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsTrashMock := mocks.NewGoodsTrash(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsTrashMock.On("UndeleteGoods", int64(3), "admin").
+					Return(storage.Goods{ID: 3, Title: "Tea", Price: 999, Currency: "USD", Version: 2}, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/goods/{id}/restore", trash.NewRestore(slogdiscard.NewDiscardLogger(), goodsTrashMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/goods/"+tc.id+"/restore", nil)
+			require.NoError(t, err)
+
+			req.SetBasicAuth("admin", "secret")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var res read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+			require.Equal(t, tc.respError, res.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "Tea", res.Title)
+				require.Equal(t, `"2"`, rr.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestPurgeHandler(t *testing.T) {
+	goodsTrashMock := mocks.NewGoodsTrash(t)
+
+	goodsTrashMock.On("PurgeGoods", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour && time.Since(before) < 25*time.Hour
+	})).Return(int64(2), nil).Once()
+
+	req, err := http.NewRequest(http.MethodDelete, "/goods/trash?olderThan=24h", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	trash.NewPurge(slogdiscard.NewDiscardLogger(), goodsTrashMock).ServeHTTP(rr, req)
+
+	var res trash.PurgeResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Empty(t, res.Error)
+	require.Equal(t, int64(2), res.Purged)
+}
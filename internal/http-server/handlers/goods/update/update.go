@@ -0,0 +1,248 @@
+package update
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/goods/save"
+	"go-api/internal/lib/api/etag"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/mergepatch"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsUpdater
+type GoodsUpdater interface {
+	GetGoods(id int64) (storage.Goods, error)
+	UpdateGoods(goods storage.Goods, version int64, actor string) (storage.Goods, error)
+}
+
+// New replaces the goods with the request body (PUT).
+func New(log *slog.Logger, goodsUpdater GoodsUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.update.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, version, ok := parseTarget(w, r, log)
+		if !ok {
+			return
+		}
+
+		var req save.Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		update(w, r, log, goodsUpdater, id, version, req)
+	}
+}
+
+// NewPatch applies a JSON merge patch (RFC 7386) to the goods (PATCH).
+func NewPatch(log *slog.Logger, goodsUpdater GoodsUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.update.NewPatch"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, version, ok := parseTarget(w, r, log)
+		if !ok {
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		current, err := goodsUpdater.GetGoods(id)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		if current.Version != version {
+			log.Info("goods version mismatch", slog.Int64("id", id), slog.Int64("version", current.Version))
+
+			w.Header().Set("ETag", etag.Format(current.Version))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, resp.Error("goods was modified"))
+
+			return
+		}
+
+		req, err := applyPatch(current, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("patch applied", slog.Any("request", req))
+
+		update(w, r, log, goodsUpdater, id, version, req)
+	}
+}
+
+// parseTarget reads the goods id and the If-Match version, writing an
+// error response if either is missing or malformed.
+func parseTarget(w http.ResponseWriter, r *http.Request, log *slog.Logger) (int64, int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Info("invalid goods id", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("invalid request"))
+
+		return 0, 0, false
+	}
+
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		log.Info("If-Match header is missing")
+
+		render.Status(r, http.StatusPreconditionRequired)
+		render.JSON(w, r, resp.Error("If-Match header is required"))
+
+		return 0, 0, false
+	}
+
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		log.Info("invalid If-Match header", sl.Err(err))
+
+		render.Status(r, http.StatusPreconditionFailed)
+		render.JSON(w, r, resp.Error("invalid If-Match header"))
+
+		return 0, 0, false
+	}
+
+	return id, version, true
+}
+
+func update(w http.ResponseWriter, r *http.Request, log *slog.Logger, goodsUpdater GoodsUpdater, id int64, version int64, req save.Request) {
+	goods, err := save.Validate(req)
+	if err != nil {
+		log.Error("invalid request", sl.Err(err))
+
+		render.JSON(w, r, save.ValidationResponse(err))
+
+		return
+	}
+
+	goods.ID = id
+
+	actor, _, _ := r.BasicAuth()
+
+	updated, err := goodsUpdater.UpdateGoods(goods, version, actor)
+	if errors.Is(err, storage.ErrGoodsNotFound) {
+		log.Info("goods not found", slog.Int64("id", id))
+
+		render.JSON(w, r, resp.Error("not found"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrImageNotFound) {
+		log.Info("image not found", slog.Int64("image_id", goods.ImageID))
+
+		render.JSON(w, r, resp.Error("image not found"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrGoodsVersionMismatch) {
+		log.Info("goods version mismatch", slog.Int64("id", id))
+
+		render.Status(r, http.StatusPreconditionFailed)
+		render.JSON(w, r, resp.Error("goods was modified"))
+
+		return
+	}
+	if err != nil {
+		log.Error("failed to update goods", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("failed to update goods"))
+
+		return
+	}
+
+	log.Info("goods updated", slog.Int64("id", id), slog.Int64("version", updated.Version))
+
+	w.Header().Set("ETag", etag.Format(updated.Version))
+
+	render.JSON(w, r, save.ToResponse(updated))
+}
+
+func applyPatch(current storage.Goods, patch []byte) (save.Request, error) {
+	doc, err := json.Marshal(save.Request{
+		Title:       current.Title,
+		Price:       money.Format(current.Price, current.Currency),
+		Currency:    current.Currency,
+		Description: current.Description,
+		ImgUrl:      current.ImgUrl,
+		ImageId:     formatID(current.ImageID),
+		Weight:      strconv.FormatInt(int64(current.Weight), 10),
+	})
+	if err != nil {
+		return save.Request{}, err
+	}
+
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return save.Request{}, err
+	}
+
+	var req save.Request
+
+	if err := json.Unmarshal(merged, &req); err != nil {
+		return save.Request{}, err
+	}
+
+	return req, nil
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,243 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/save"
+	"go-api/internal/http-server/handlers/goods/update"
+	"go-api/internal/http-server/handlers/goods/update/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var current = storage.Goods{
+	ID:          1,
+	Title:       "Tea",
+	Price:       950,
+	Currency:    "USD",
+	Description: "Green tea",
+	ImgUrl:      "https://example.com/tea.png",
+	Weight:      100,
+	Version:     3,
+}
+
+func TestPutHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		ifMatch   string
+		body      string
+		code      int
+		etag      string
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Success",
+			ifMatch: `"3"`,
+			body:    `{"title":"Coffee","price":"12.5","currency":"USD","imgUrl":"https://example.com/c.png","weight":"250"}`,
+			code:    http.StatusOK,
+			etag:    `"4"`,
+		},
+		{
+			name:      "Missing If-Match",
+			body:      `{"title":"Coffee","price":"12.5","currency":"USD","imgUrl":"https://example.com/c.png","weight":"250"}`,
+			code:      http.StatusPreconditionRequired,
+			respError: "If-Match header is required",
+		},
+		{
+			name:      "Stale version",
+			ifMatch:   `"2"`,
+			body:      `{"title":"Coffee","price":"12.5","currency":"USD","imgUrl":"https://example.com/c.png","weight":"250"}`,
+			code:      http.StatusPreconditionFailed,
+			respError: "goods was modified",
+			mockError: storage.ErrGoodsVersionMismatch,
+		},
+		{
+			name:      "Empty title",
+			ifMatch:   `"3"`,
+			body:      `{"price":"12.5","currency":"USD","imgUrl":"https://example.com/c.png","weight":"250"}`,
+			code:      http.StatusOK,
+			respError: "field Title is a required field",
+		},
+		{
+			name:      "Invalid price",
+			ifMatch:   `"3"`,
+			body:      `{"title":"Coffee","price":"cheap","currency":"USD","imgUrl":"https://example.com/c.png","weight":"250"}`,
+			code:      http.StatusOK,
+			respError: "failed parse price value",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsUpdaterMock := mocks.NewGoodsUpdater(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsUpdaterMock.On("UpdateGoods", mock.AnythingOfType("storage.Goods"), mock.AnythingOfType("int64"), "admin").
+					Return(func(g storage.Goods, version int64, actor string) (storage.Goods, error) {
+						g.Version = version + 1
+
+						return g, tc.mockError
+					}).Once()
+			}
+
+			rr := serve(t, http.MethodPut, update.New(slogdiscard.NewDiscardLogger(), goodsUpdaterMock), tc.ifMatch, tc.body)
+
+			require.Equal(t, tc.code, rr.Code)
+			require.Equal(t, tc.etag, rr.Header().Get("ETag"))
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func TestPatchHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		ifMatch   string
+		body      string
+		code      int
+		want      storage.Goods
+		respError string
+	}{
+		{
+			name:    "Change price",
+			ifMatch: `"3"`,
+			body:    `{"price":"11"}`,
+			code:    http.StatusOK,
+			want: storage.Goods{
+				ID: 1, Title: "Tea", Price: 1100, Currency: "USD", Description: "Green tea", ImgUrl: "https://example.com/tea.png", Weight: 100,
+			},
+		},
+		{
+			name:    "Change currency",
+			ifMatch: `"3"`,
+			body:    `{"price":"1400","currency":"JPY"}`,
+			code:    http.StatusOK,
+			want: storage.Goods{
+				ID: 1, Title: "Tea", Price: 1400, Currency: "JPY", Description: "Green tea", ImgUrl: "https://example.com/tea.png", Weight: 100,
+			},
+		},
+		{
+			name:      "Too precise for currency",
+			ifMatch:   `"3"`,
+			body:      `{"currency":"JPY"}`,
+			code:      http.StatusOK,
+			respError: "too many decimal places for currency",
+		},
+		{
+			name:    "Replace image URL with uploaded image",
+			ifMatch: `"3"`,
+			body:    `{"imgUrl":null,"imageId":"7"}`,
+			code:    http.StatusOK,
+			want: storage.Goods{
+				ID: 1, Title: "Tea", Price: 950, Currency: "USD", Description: "Green tea", ImageID: 7, Weight: 100,
+			},
+		},
+		{
+			name:      "Remove image URL without image",
+			ifMatch:   `"3"`,
+			body:      `{"imgUrl":null}`,
+			code:      http.StatusOK,
+			respError: "field ImgUrl is a required field",
+		},
+		{
+			name:    "Remove description",
+			ifMatch: `"3"`,
+			body:    `{"description":null}`,
+			code:    http.StatusOK,
+			want: storage.Goods{
+				ID: 1, Title: "Tea", Price: 950, Currency: "USD", ImgUrl: "https://example.com/tea.png", Weight: 100,
+			},
+		},
+		{
+			name:      "Remove required field",
+			ifMatch:   `"3"`,
+			body:      `{"title":null}`,
+			code:      http.StatusOK,
+			respError: "field Title is a required field",
+		},
+		{
+			name:      "Stale version",
+			ifMatch:   `"1"`,
+			body:      `{"price":"11"}`,
+			code:      http.StatusPreconditionFailed,
+			respError: "goods was modified",
+		},
+		{
+			name:      "Not an object",
+			ifMatch:   `"3"`,
+			body:      `["price"]`,
+			code:      http.StatusOK,
+			respError: "failed to decode request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsUpdaterMock := mocks.NewGoodsUpdater(t)
+
+			goodsUpdaterMock.On("GetGoods", current.ID).
+				Return(current, nil).Once()
+
+			if tc.respError == "" {
+				updated := tc.want
+				updated.Version = current.Version + 1
+
+				goodsUpdaterMock.On("UpdateGoods", tc.want, current.Version, "admin").
+					Return(updated, nil).Once()
+			}
+
+			rr := serve(t, http.MethodPatch, update.NewPatch(slogdiscard.NewDiscardLogger(), goodsUpdaterMock), tc.ifMatch, tc.body)
+
+			require.Equal(t, tc.code, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func serve(t *testing.T, method string, handler http.HandlerFunc, ifMatch string, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.MethodFunc(method, "/goods/{id}", handler)
+
+	req, err := http.NewRequest(method, "/goods/1", bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	req.SetBasicAuth("admin", "secret")
+
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	return rr
+}
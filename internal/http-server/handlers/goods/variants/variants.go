@@ -0,0 +1,356 @@
+package variants
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/goods/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+// Request describes a variant. Price and weight are optional overrides of
+// those of the goods; the price is in the goods currency. On update the
+// SKU is taken from the URL.
+type Request struct {
+	Sku     string            `json:"sku" validate:"required,max=64,printascii,excludesall=/?#%"`
+	Options map[string]string `json:"options" validate:"max=10,dive,keys,required,max=32,endkeys,required,max=64"`
+	Price   *string           `json:"price,omitempty"`
+	Weight  *string           `json:"weight,omitempty"`
+	Stock   int64             `json:"stock" validate:"min=0"`
+}
+
+type Response struct {
+	resp.Response
+	read.Variant
+}
+
+type ListResponse struct {
+	resp.Response
+	Variants []read.Variant `json:"variants"`
+}
+
+var (
+	ErrInvalidPrice  = errors.New("failed parse price value")
+	ErrInvalidWeight = errors.New("failed parse weight value")
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=GoodsVariants
+type GoodsVariants interface {
+	GetGoods(id int64) (storage.Goods, error)
+	ListVariants(goodsID int64) ([]storage.Variant, error)
+	SaveVariant(variant storage.Variant) (int64, error)
+	UpdateVariant(variant storage.Variant) error
+	DeleteVariant(goodsID int64, sku string) error
+}
+
+// New lists the variants of the goods.
+func New(log *slog.Logger, goodsVariants GoodsVariants) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.variants.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		goods, ok := getGoods(w, r, log, goodsVariants)
+		if !ok {
+			return
+		}
+
+		variants, err := goodsVariants.ListVariants(goods.ID)
+		if err != nil {
+			log.Error("failed to list goods variants", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed goods variants", slog.Int64("id", goods.ID), slog.Int("count", len(variants)))
+
+		render.JSON(w, r, ListResponse{
+			Response: resp.OK(),
+			Variants: read.ToVariants(goods, variants),
+		})
+	}
+}
+
+// NewSave adds a variant to the goods.
+func NewSave(log *slog.Logger, goodsVariants GoodsVariants) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.variants.NewSave"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		goods, variant, ok := decodeVariant(w, r, log, goodsVariants, "")
+		if !ok {
+			return
+		}
+
+		id, err := goodsVariants.SaveVariant(variant)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("id", goods.ID))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrVariantExists) {
+			log.Info("variant already exists", slog.String("sku", variant.SKU))
+
+			render.JSON(w, r, resp.Error(storage.ErrVariantExists.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to add variant", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to add variant"))
+
+			return
+		}
+
+		log.Info("variant added", slog.Int64("id", id), slog.String("sku", variant.SKU))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Variant:  read.ToVariant(goods, variant),
+		})
+	}
+}
+
+// NewUpdate replaces the variant with the sku URL parameter.
+func NewUpdate(log *slog.Logger, goodsVariants GoodsVariants) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.variants.NewUpdate"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		goods, variant, ok := decodeVariant(w, r, log, goodsVariants, chi.URLParam(r, "sku"))
+		if !ok {
+			return
+		}
+
+		err := goodsVariants.UpdateVariant(variant)
+		if errors.Is(err, storage.ErrVariantNotFound) {
+			log.Info("variant not found", slog.Int64("id", goods.ID), slog.String("sku", variant.SKU))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrVariantExists) {
+			log.Info("variant options already used", slog.String("sku", variant.SKU))
+
+			render.JSON(w, r, resp.Error(storage.ErrVariantExists.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to update variant", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to update variant"))
+
+			return
+		}
+
+		log.Info("variant updated", slog.Int64("id", goods.ID), slog.String("sku", variant.SKU))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Variant:  read.ToVariant(goods, variant),
+		})
+	}
+}
+
+// NewRemove deletes the variant with the sku URL parameter.
+func NewRemove(log *slog.Logger, goodsVariants GoodsVariants) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.goods.variants.NewRemove"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		sku := chi.URLParam(r, "sku")
+
+		err = goodsVariants.DeleteVariant(id, sku)
+		if errors.Is(err, storage.ErrVariantNotFound) {
+			log.Info("variant not found", slog.Int64("id", id), slog.String("sku", sku))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete variant", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("variant deleted", slog.Int64("id", id), slog.String("sku", sku))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
+
+// Validate checks req and converts it to a variant of goods. Besides
+// validator.ValidationErrors it may return ErrInvalidPrice wrapping a
+// money error or ErrInvalidWeight.
+func Validate(req Request, goods storage.Goods) (storage.Variant, error) {
+	if err := validator.New().Struct(req); err != nil {
+		return storage.Variant{}, err
+	}
+
+	variant := storage.Variant{
+		GoodsID: goods.ID,
+		SKU:     req.Sku,
+		Options: req.Options,
+		Stock:   req.Stock,
+	}
+
+	if variant.Options == nil {
+		variant.Options = map[string]string{}
+	}
+
+	if req.Price != nil {
+		price, err := money.Parse(*req.Price, goods.Currency)
+		if err != nil {
+			return storage.Variant{}, fmt.Errorf("%w: %w", ErrInvalidPrice, err)
+		}
+		if price < 0 {
+			return storage.Variant{}, fmt.Errorf("%w: negative price", ErrInvalidPrice)
+		}
+
+		variant.Price = &price
+	}
+
+	if req.Weight != nil {
+		weight, err := strconv.ParseInt(*req.Weight, 10, 32)
+		if err != nil {
+			return storage.Variant{}, fmt.Errorf("%w: %w", ErrInvalidWeight, err)
+		}
+		if weight < 0 {
+			return storage.Variant{}, fmt.Errorf("%w: negative weight", ErrInvalidWeight)
+		}
+
+		w := int32(weight)
+		variant.Weight = &w
+	}
+
+	return variant, nil
+}
+
+// ValidationResponse converts an error returned by Validate to a response.
+func ValidationResponse(err error) resp.Response {
+	var validateErr validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &validateErr):
+		return resp.ValidationError(validateErr)
+	case errors.Is(err, money.ErrTooPrecise):
+		return resp.Error(money.ErrTooPrecise.Error())
+	case errors.Is(err, ErrInvalidPrice):
+		return resp.Error(ErrInvalidPrice.Error())
+	case errors.Is(err, ErrInvalidWeight):
+		return resp.Error(ErrInvalidWeight.Error())
+	default:
+		return resp.Error("invalid request")
+	}
+}
+
+// decodeVariant reads the goods and the variant of the request. A non-empty
+// sku overrides the one in the body.
+func decodeVariant(w http.ResponseWriter, r *http.Request, log *slog.Logger, goodsVariants GoodsVariants, sku string) (storage.Goods, storage.Variant, bool) {
+	goods, ok := getGoods(w, r, log, goodsVariants)
+	if !ok {
+		return storage.Goods{}, storage.Variant{}, false
+	}
+
+	var req Request
+
+	err := render.DecodeJSON(r.Body, &req)
+	if err != nil {
+		log.Error("failed to decode request body", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("failed to decode request"))
+
+		return storage.Goods{}, storage.Variant{}, false
+	}
+
+	if sku != "" {
+		req.Sku = sku
+	}
+
+	log.Info("request body decoded", slog.Any("request", req))
+
+	variant, err := Validate(req, goods)
+	if err != nil {
+		log.Info("invalid request", sl.Err(err))
+
+		render.JSON(w, r, ValidationResponse(err))
+
+		return storage.Goods{}, storage.Variant{}, false
+	}
+
+	return goods, variant, true
+}
+
+func getGoods(w http.ResponseWriter, r *http.Request, log *slog.Logger, goodsVariants GoodsVariants) (storage.Goods, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Info("invalid goods id", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("invalid request"))
+
+		return storage.Goods{}, false
+	}
+
+	goods, err := goodsVariants.GetGoods(id)
+	if errors.Is(err, storage.ErrGoodsNotFound) {
+		log.Info("goods not found", slog.Int64("id", id))
+
+		render.JSON(w, r, resp.Error("not found"))
+
+		return storage.Goods{}, false
+	}
+	if err != nil {
+		log.Error("failed to get goods", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return storage.Goods{}, false
+	}
+
+	return goods, true
+}
@@ -0,0 +1,330 @@
+package variants_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/goods/variants"
+	"go-api/internal/http-server/handlers/goods/variants/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var shirt = storage.Goods{ID: 1, Title: "Shirt", Price: 1500, Currency: "USD", Weight: 200}
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		variant   storage.Variant
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Success",
+			body:    `{"sku": "SHIRT-M-RED", "options": {"size": "M", "color": "red"}, "stock": 4}`,
+			variant: storage.Variant{GoodsID: 1, SKU: "SHIRT-M-RED", Options: map[string]string{"size": "M", "color": "red"}, Stock: 4},
+		},
+		{
+			name:    "Overrides",
+			body:    `{"sku": "SHIRT-XL", "options": {"size": "XL"}, "price": "17.5", "weight": "260"}`,
+			variant: storage.Variant{GoodsID: 1, SKU: "SHIRT-XL", Options: map[string]string{"size": "XL"}, Price: ptr(int64(1750)), Weight: ptr(int32(260))},
+		},
+		{
+			name:      "Missing sku",
+			body:      `{"options": {"size": "M"}}`,
+			respError: "field Sku is a required field",
+		},
+		{
+			name:      "Invalid sku",
+			body:      `{"sku": "SHIRT/M"}`,
+			respError: "field Sku is not valid",
+		},
+		{
+			name:      "Negative stock",
+			body:      `{"sku": "SHIRT-M", "stock": -1}`,
+			respError: "field Stock is not valid",
+		},
+		{
+			name:      "Too precise price",
+			body:      `{"sku": "SHIRT-M", "price": "1.005"}`,
+			respError: "too many decimal places for currency",
+		},
+		{
+			name:      "Invalid weight",
+			body:      `{"sku": "SHIRT-M", "weight": "heavy"}`,
+			respError: "failed parse weight value",
+		},
+		{
+			name:      "Exists",
+			body:      `{"sku": "SHIRT-M", "options": {"size": "M"}}`,
+			variant:   storage.Variant{GoodsID: 1, SKU: "SHIRT-M", Options: map[string]string{"size": "M"}},
+			respError: storage.ErrVariantExists.Error(),
+			mockError: storage.ErrVariantExists,
+		},
+		{
+			name:      "SaveVariant Error",
+			body:      `{"sku": "SHIRT-M"}`,
+			variant:   storage.Variant{GoodsID: 1, SKU: "SHIRT-M", Options: map[string]string{}},
+			respError: "failed to add variant",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsVariantsMock := mocks.NewGoodsVariants(t)
+
+			goodsVariantsMock.On("GetGoods", int64(1)).
+				Return(shirt, nil).Once()
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsVariantsMock.On("SaveVariant", tc.variant).
+					Return(int64(1), tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/goods/{id}/variants", variants.NewSave(slogdiscard.NewDiscardLogger(), goodsVariantsMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/goods/1/variants", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp variants.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.variant.SKU, resp.Sku)
+				require.Equal(t, tc.variant.Options, resp.Options)
+			}
+		})
+	}
+}
+
+func TestSaveEffectiveValues(t *testing.T) {
+	goodsVariantsMock := mocks.NewGoodsVariants(t)
+
+	goodsVariantsMock.On("GetGoods", int64(1)).
+		Return(shirt, nil).Twice()
+	goodsVariantsMock.On("SaveVariant", mock.AnythingOfType("storage.Variant")).
+		Return(int64(1), nil).Twice()
+
+	r := chi.NewRouter()
+	r.Post("/goods/{id}/variants", variants.NewSave(slogdiscard.NewDiscardLogger(), goodsVariantsMock))
+
+	for _, tc := range []struct {
+		body   string
+		price  string
+		weight int32
+	}{
+		{body: `{"sku": "A"}`, price: "15.00", weight: 200},
+		{body: `{"sku": "B", "price": "0", "weight": "0"}`, price: "0.00", weight: 0},
+	} {
+		req, err := http.NewRequest(http.MethodPost, "/goods/1/variants", bytes.NewReader([]byte(tc.body)))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		var resp variants.Response
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Empty(t, resp.Error)
+		require.Equal(t, tc.price, resp.Price)
+		require.Equal(t, tc.weight, resp.Weight)
+	}
+}
+
+func TestUpdateHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		sku       string
+		body      string
+		goodsErr  error
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			sku:  "SHIRT-M",
+			body: `{"options": {"size": "M"}, "stock": 10}`,
+		},
+		{
+			name: "Sku from url",
+			sku:  "SHIRT-M",
+			body: `{"sku": "OTHER", "options": {"size": "M"}}`,
+		},
+		{
+			name:      "Goods not found",
+			sku:       "SHIRT-M",
+			body:      `{}`,
+			goodsErr:  storage.ErrGoodsNotFound,
+			respError: "not found",
+		},
+		{
+			name:      "Variant not found",
+			sku:       "SHIRT-S",
+			body:      `{"options": {"size": "S"}}`,
+			respError: "not found",
+			mockError: storage.ErrVariantNotFound,
+		},
+		{
+			name:      "Options used",
+			sku:       "SHIRT-S",
+			body:      `{"options": {"size": "M"}}`,
+			respError: storage.ErrVariantExists.Error(),
+			mockError: storage.ErrVariantExists,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsVariantsMock := mocks.NewGoodsVariants(t)
+
+			goodsVariantsMock.On("GetGoods", int64(1)).
+				Return(shirt, tc.goodsErr).Once()
+
+			if tc.goodsErr == nil {
+				goodsVariantsMock.On("UpdateVariant", mock.MatchedBy(func(variant storage.Variant) bool {
+					return variant.GoodsID == 1 && variant.SKU == tc.sku
+				})).Return(tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Put("/goods/{id}/variants/{sku}", variants.NewUpdate(slogdiscard.NewDiscardLogger(), goodsVariantsMock))
+
+			req, err := http.NewRequest(http.MethodPut, "/goods/1/variants/"+tc.sku, bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp variants.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.sku, resp.Sku)
+			}
+		})
+	}
+}
+
+func TestListHandler(t *testing.T) {
+	goodsVariantsMock := mocks.NewGoodsVariants(t)
+
+	goodsVariantsMock.On("GetGoods", int64(1)).
+		Return(shirt, nil).Once()
+	goodsVariantsMock.On("ListVariants", int64(1)).
+		Return([]storage.Variant{
+			{GoodsID: 1, SKU: "SHIRT-S", Options: map[string]string{"size": "S"}, Stock: 2},
+			{GoodsID: 1, SKU: "SHIRT-XL", Options: map[string]string{"size": "XL"}, Price: ptr(int64(1750))},
+		}, nil).Once()
+
+	r := chi.NewRouter()
+	r.Get("/goods/{id}/variants", variants.New(slogdiscard.NewDiscardLogger(), goodsVariantsMock))
+
+	req, err := http.NewRequest(http.MethodGet, "/goods/1/variants", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	var resp variants.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+	require.Len(t, resp.Variants, 2)
+	require.Equal(t, "15.00", resp.Variants[0].Price)
+	require.Equal(t, int64(2), resp.Variants[0].Stock)
+	require.Equal(t, "17.50", resp.Variants[1].Price)
+	require.Equal(t, int32(200), resp.Variants[1].Weight)
+}
+
+func TestRemoveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		id        string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			id:   "1",
+		},
+		{
+			name:      "Invalid id",
+			id:        "abc",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not found",
+			id:        "1",
+			respError: "not found",
+			mockError: storage.ErrVariantNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			goodsVariantsMock := mocks.NewGoodsVariants(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				goodsVariantsMock.On("DeleteVariant", int64(1), "SHIRT-M").
+					Return(tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Delete("/goods/{id}/variants/{sku}", variants.NewRemove(slogdiscard.NewDiscardLogger(), goodsVariantsMock))
+
+			req, err := http.NewRequest(http.MethodDelete, "/goods/"+tc.id+"/variants/SHIRT-M", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp variants.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,184 @@
+package read
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/blob"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+// Image is the JSON representation of an uploaded image.
+type Image struct {
+	Id          string            `json:"id"`
+	Url         string            `json:"url"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ImageGetter
+type ImageGetter interface {
+	GetImage(id int64) (storage.Image, error)
+}
+
+// New serves the original of an uploaded image.
+func New(log *slog.Logger, imageGetter ImageGetter, blobs blob.Storage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.image.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		image, ok := getImage(w, r, log, imageGetter)
+		if !ok {
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.FormatInt(image.Size, 10))
+
+		serve(w, r, log, blobs, image.Key+"/original", image.ContentType)
+	}
+}
+
+// NewThumbnail serves a thumbnail of an uploaded image in one of the
+// sizes generated on upload.
+func NewThumbnail(log *slog.Logger, imageGetter ImageGetter, blobs blob.Storage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.image.read.NewThumbnail"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		size, err := strconv.Atoi(chi.URLParam(r, "size"))
+		if err != nil {
+			log.Info("invalid thumbnail size", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		image, ok := getImage(w, r, log, imageGetter)
+		if !ok {
+			return
+		}
+
+		if !slices.Contains(image.Thumbnails, size) {
+			log.Info("thumbnail not found", slog.Int64("id", image.ID), slog.Int("size", size))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+
+		serve(w, r, log, blobs, ThumbnailKey(image.Key, size), ThumbnailType(image.ContentType))
+	}
+}
+
+// URL is the path the image is served at.
+func URL(id int64) string {
+	return "/images/" + strconv.FormatInt(id, 10)
+}
+
+// ThumbnailKey is the blob key of a thumbnail of the image stored under key.
+func ThumbnailKey(key string, size int) string {
+	return key + "/" + strconv.Itoa(size)
+}
+
+// ThumbnailType is the content type of the thumbnails of an image:
+// photos stay JPEG, everything else becomes PNG to keep transparency.
+func ThumbnailType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+
+	return "image/png"
+}
+
+// ToImage converts storage.Image to its JSON representation.
+func ToImage(image storage.Image) Image {
+	url := URL(image.ID)
+
+	thumbnails := make(map[string]string, len(image.Thumbnails))
+	for _, size := range image.Thumbnails {
+		thumbnails[strconv.Itoa(size)] = url + "/thumbnails/" + strconv.Itoa(size)
+	}
+
+	return Image{
+		Id:          strconv.FormatInt(image.ID, 10),
+		Url:         url,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Width:       image.Width,
+		Height:      image.Height,
+		Thumbnails:  thumbnails,
+	}
+}
+
+func getImage(w http.ResponseWriter, r *http.Request, log *slog.Logger, imageGetter ImageGetter) (storage.Image, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		log.Info("invalid image id", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("invalid request"))
+
+		return storage.Image{}, false
+	}
+
+	image, err := imageGetter.GetImage(id)
+	if errors.Is(err, storage.ErrImageNotFound) {
+		log.Info("image not found", slog.Int64("id", id))
+
+		render.JSON(w, r, resp.Error("not found"))
+
+		return storage.Image{}, false
+	}
+	if err != nil {
+		log.Error("failed to get image", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return storage.Image{}, false
+	}
+
+	return image, true
+}
+
+func serve(w http.ResponseWriter, r *http.Request, log *slog.Logger, blobs blob.Storage, key string, contentType string) {
+	body, err := blobs.Get(key)
+	if err != nil {
+		log.Error("failed to get image blob", slog.String("key", key), sl.Err(err))
+
+		w.Header().Del("Content-Length")
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return
+	}
+	defer body.Close()
+
+	// blobs are never overwritten, a new upload gets a new key
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err := io.Copy(w, body); err != nil {
+		log.Error("failed to send image", slog.String("key", key), sl.Err(err))
+	}
+}
@@ -0,0 +1,91 @@
+package read_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/blob/filesystem"
+	"go-api/internal/http-server/handlers/image/read"
+	"go-api/internal/http-server/handlers/image/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestReadHandler(t *testing.T) {
+	blobs, err := filesystem.New(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, blobs.Put("abc/original", strings.NewReader("original")))
+	require.NoError(t, blobs.Put("abc/128", strings.NewReader("thumbnail")))
+
+	image := storage.Image{ID: 1, Key: "abc", ContentType: "image/gif", Size: 8, Thumbnails: []int{128}}
+
+	cases := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		respError   string
+	}{
+		{
+			name:        "Original",
+			path:        "/images/1",
+			contentType: "image/gif",
+			body:        "original",
+		},
+		{
+			name:        "Thumbnail",
+			path:        "/images/1/thumbnails/128",
+			contentType: "image/png",
+			body:        "thumbnail",
+		},
+		{
+			name:      "Unknown size",
+			path:      "/images/1/thumbnails/64",
+			respError: "not found",
+		},
+		{
+			name:      "Unknown image",
+			path:      "/images/2",
+			respError: "not found",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			imageGetterMock := mocks.NewImageGetter(t)
+			imageGetterMock.On("GetImage", int64(1)).Return(image, nil).Maybe()
+			imageGetterMock.On("GetImage", int64(2)).Return(storage.Image{}, storage.ErrImageNotFound).Maybe()
+
+			r := chi.NewRouter()
+			r.Get("/images/{id}", read.New(slogdiscard.NewDiscardLogger(), imageGetterMock, blobs))
+			r.Get("/images/{id}/thumbnails/{size}", read.NewThumbnail(slogdiscard.NewDiscardLogger(), imageGetterMock, blobs))
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			if tc.respError != "" {
+				require.JSONEq(t, `{"status":"Error","error":"`+tc.respError+`"}`, rr.Body.String())
+
+				return
+			}
+
+			require.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+			require.Equal(t, tc.body, rr.Body.String())
+		})
+	}
+}
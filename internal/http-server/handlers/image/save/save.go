@@ -0,0 +1,258 @@
+package save
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/blob"
+	"go-api/internal/http-server/handlers/image/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/thumbnail"
+	"go-api/internal/storage"
+)
+
+// formField is the multipart form field holding the uploaded file.
+const formField = "file"
+
+// maxPixels guards against small files that decode into huge images.
+const maxPixels = 50_000_000
+
+var (
+	ErrTooLarge        = errors.New("image is too large")
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrNoFile          = errors.New("file is required")
+)
+
+// allowedTypes are the sniffed content types accepted for upload.
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type Response struct {
+	resp.Response
+	read.Image
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ImageSaver
+type ImageSaver interface {
+	SaveImage(image storage.Image) (int64, error)
+}
+
+// New stores an image uploaded as the file field of a multipart form
+// together with its thumbnails in thumbnailSizes.
+func New(log *slog.Logger, imageSaver ImageSaver, blobs blob.Storage, maxSize int64, thumbnailSizes []int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.image.save.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		data, err := readFile(r, maxSize)
+		if errors.Is(err, ErrTooLarge) || errors.Is(err, ErrNoFile) {
+			log.Info("invalid upload", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to read upload", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		img, stored, err := decode(data)
+		if errors.Is(err, ErrTooLarge) || errors.Is(err, ErrUnsupportedType) {
+			log.Info("invalid image", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Info("failed to decode image", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid image"))
+
+			return
+		}
+
+		stored.Key, err = newKey()
+		if err != nil {
+			log.Error("failed to generate image key", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		keys, err := store(blobs, stored, data, img, thumbnailSizes)
+		if err != nil {
+			log.Error("failed to store image", sl.Err(err))
+
+			deleteBlobs(log, blobs, keys)
+
+			render.JSON(w, r, resp.Error("failed to save image"))
+
+			return
+		}
+
+		stored.Thumbnails = thumbnailSizes
+
+		id, err := imageSaver.SaveImage(stored)
+		if err != nil {
+			log.Error("failed to save image", sl.Err(err))
+
+			deleteBlobs(log, blobs, keys)
+
+			render.JSON(w, r, resp.Error("failed to save image"))
+
+			return
+		}
+
+		stored.ID = id
+
+		log.Info("image saved", slog.Int64("id", id), slog.String("content_type", stored.ContentType))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Image:    read.ToImage(stored),
+		})
+	}
+}
+
+// readFile reads the file field of a multipart form without buffering
+// more than maxSize bytes of it.
+func readFile(r *http.Request, maxSize int64) ([]byte, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoFile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() != formField {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > maxSize {
+			return nil, ErrTooLarge
+		}
+
+		return data, nil
+	}
+}
+
+// decode checks the sniffed content type and the dimensions of the image
+// before decoding it.
+func decode(data []byte) (image.Image, storage.Image, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedTypes[contentType] {
+		return nil, storage.Image{}, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, storage.Image{}, err
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, storage.Image{}, fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, storage.Image{}, err
+	}
+
+	return img, storage.Image{
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}, nil
+}
+
+// store puts the original and the thumbnails into blob storage and
+// returns the keys written so far, also on error.
+func store(blobs blob.Storage, stored storage.Image, data []byte, img image.Image, sizes []int) ([]string, error) {
+	var keys []string
+
+	key := stored.Key + "/original"
+	if err := blobs.Put(key, bytes.NewReader(data)); err != nil {
+		return keys, err
+	}
+	keys = append(keys, key)
+
+	for _, size := range sizes {
+		var buf bytes.Buffer
+
+		thumb := thumbnail.Resize(img, size)
+
+		var err error
+		if read.ThumbnailType(stored.ContentType) == "image/jpeg" {
+			err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, thumb)
+		}
+		if err != nil {
+			return keys, err
+		}
+
+		key := read.ThumbnailKey(stored.Key, size)
+		if err := blobs.Put(key, &buf); err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func deleteBlobs(log *slog.Logger, blobs blob.Storage, keys []string) {
+	for _, key := range keys {
+		if err := blobs.Delete(key); err != nil {
+			log.Error("failed to delete image blob", slog.String("key", key), sl.Err(err))
+		}
+	}
+}
+
+func newKey() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,199 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/blob/filesystem"
+	"go-api/internal/http-server/handlers/image/save"
+	"go-api/internal/http-server/handlers/image/save/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		field     string
+		file      []byte
+		maxSize   int64
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Success",
+			field:   "file",
+			file:    pngImage(t, 600, 300),
+			maxSize: 1 << 20,
+		},
+		{
+			name:      "Too large",
+			field:     "file",
+			file:      pngImage(t, 600, 300),
+			maxSize:   100,
+			respError: "image is too large",
+		},
+		{
+			name:      "Not an image",
+			field:     "file",
+			file:      []byte("<html><body>hello</body></html>"),
+			maxSize:   1 << 20,
+			respError: "unsupported image type: text/html; charset=utf-8",
+		},
+		{
+			name:      "Missing file",
+			field:     "picture",
+			file:      pngImage(t, 10, 10),
+			maxSize:   1 << 20,
+			respError: "file is required",
+		},
+		{
+			name:      "SaveImage Error",
+			field:     "file",
+			file:      pngImage(t, 10, 10),
+			maxSize:   1 << 20,
+			respError: "failed to save image",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+
+			blobs, err := filesystem.New(root)
+			require.NoError(t, err)
+
+			imageSaverMock := mocks.NewImageSaver(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				imageSaverMock.On("SaveImage", mock.AnythingOfType("storage.Image")).
+					Return(int64(1), tc.mockError).Once()
+			}
+
+			var body bytes.Buffer
+
+			mw := multipart.NewWriter(&body)
+			fw, err := mw.CreateFormFile(tc.field, "image.png")
+			require.NoError(t, err)
+			_, err = fw.Write(tc.file)
+			require.NoError(t, err)
+			require.NoError(t, mw.Close())
+
+			req, err := http.NewRequest(http.MethodPost, "/images", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+
+			rr := httptest.NewRecorder()
+			save.New(slogdiscard.NewDiscardLogger(), imageSaverMock, blobs, tc.maxSize, []int{128, 512}).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			entries, err := os.ReadDir(root)
+			require.NoError(t, err)
+
+			if tc.respError != "" {
+				// nothing is left behind by a failed upload
+				for _, e := range entries {
+					files, err := os.ReadDir(filepath.Join(root, e.Name()))
+					require.NoError(t, err)
+					require.Empty(t, files)
+				}
+
+				return
+			}
+
+			require.Equal(t, "1", resp.Id)
+			require.Equal(t, "/images/1", resp.Url)
+			require.Equal(t, "image/png", resp.ContentType)
+			require.Equal(t, 600, resp.Width)
+			require.Equal(t, "/images/1/thumbnails/128", resp.Thumbnails["128"])
+
+			require.Len(t, entries, 1)
+
+			thumb, err := os.Open(filepath.Join(root, entries[0].Name(), "128"))
+			require.NoError(t, err)
+			defer thumb.Close()
+
+			cfg, err := png.DecodeConfig(thumb)
+			require.NoError(t, err)
+			require.Equal(t, 128, cfg.Width)
+			require.Equal(t, 64, cfg.Height)
+
+			thumb, err = os.Open(filepath.Join(root, entries[0].Name(), "512"))
+			require.NoError(t, err)
+			defer thumb.Close()
+
+			cfg, err = png.DecodeConfig(thumb)
+			require.NoError(t, err)
+			require.Equal(t, 512, cfg.Width)
+		})
+	}
+}
+
+func TestSavedImage(t *testing.T) {
+	blobs, err := filesystem.New(t.TempDir())
+	require.NoError(t, err)
+
+	imageSaverMock := mocks.NewImageSaver(t)
+	imageSaverMock.On("SaveImage", mock.MatchedBy(func(image storage.Image) bool {
+		return len(image.Key) == 32 && image.Size > 0 && image.Width == 20 && image.Height == 40 &&
+			len(image.Thumbnails) == 1 && image.Thumbnails[0] == 16
+	})).Return(int64(3), nil).Once()
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "image.png")
+	require.NoError(t, err)
+	_, err = fw.Write(pngImage(t, 20, 40))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req, err := http.NewRequest(http.MethodPost, "/images", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	rr := httptest.NewRecorder()
+	save.New(slogdiscard.NewDiscardLogger(), imageSaverMock, blobs, 1<<20, []int{16}).ServeHTTP(rr, req)
+
+	var resp save.Response
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+}
@@ -0,0 +1,212 @@
+package read
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	cartRead "go-api/internal/http-server/handlers/cart/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+// Order is the JSON representation of an order. Items are only filled in
+// when a single order is read. Total is after the promotion discount, if
+// any.
+type Order struct {
+	Id            string          `json:"id"`
+	Status        string          `json:"status"`
+	Currency      string          `json:"currency"`
+	Items         []cartRead.Item `json:"items,omitempty"`
+	Discount      string          `json:"discount,omitempty"`
+	PromotionCode string          `json:"promotionCode,omitempty"`
+	Total         string          `json:"total"`
+	Weight        int64           `json:"weight"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+}
+
+// Response nests the order, as its status would clash with the status of
+// the response.
+type Response struct {
+	resp.Response
+	Order Order `json:"order"`
+}
+
+type ListRequest struct {
+	Status string `validate:"omitempty,oneof=pending paid shipped cancelled"`
+	Limit  int    `validate:"min=0,max=100"`
+	Offset int    `validate:"min=0"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Orders []Order `json:"orders"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=OrderGetter
+type OrderGetter interface {
+	GetOrder(id int64) (storage.Order, error)
+	ListOrders(status string, limit int, offset int) ([]storage.Order, error)
+}
+
+func New(log *slog.Logger, orderGetter OrderGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.order.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid order id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		order, err := orderGetter.GetOrder(id)
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			log.Info("order not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get order", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got order", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Order:    ToOrder(order),
+		})
+	}
+}
+
+// NewList lists orders, newest first, optionally in one status.
+func NewList(log *slog.Logger, orderGetter OrderGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.order.read.NewList"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		req, err := parseListRequest(r)
+		if err != nil {
+			log.Info("failed to parse query", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultLimit
+		}
+
+		orders, err := orderGetter.ListOrders(req.Status, limit, req.Offset)
+		if err != nil {
+			log.Error("failed to list orders", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed orders", slog.Int("count", len(orders)))
+
+		res := ListResponse{
+			Response: resp.OK(),
+			Orders:   make([]Order, 0, len(orders)),
+		}
+
+		for _, o := range orders {
+			res.Orders = append(res.Orders, ToOrder(o))
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parseListRequest(r *http.Request) (ListRequest, error) {
+	var (
+		req ListRequest
+		err error
+	)
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	req.Status = q.Get("status")
+
+	return req, nil
+}
+
+// ToOrder converts storage.Order to its JSON representation.
+func ToOrder(order storage.Order) Order {
+	res := Order{
+		Id:        strconv.FormatInt(order.ID, 10),
+		Status:    order.Status,
+		Currency:  order.Currency,
+		Total:     money.Format(order.Total, order.Currency),
+		Weight:    order.Weight,
+		CreatedAt: order.CreatedAt,
+		UpdatedAt: order.UpdatedAt,
+	}
+
+	if order.Items != nil {
+		res.Items = cartRead.ToItems(order.Items, order.Currency)
+	}
+
+	if order.Discount > 0 {
+		res.Discount = money.Format(order.Discount, order.Currency)
+		res.PromotionCode = order.PromotionCode
+	}
+
+	return res
+}
@@ -0,0 +1,83 @@
+package read_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/order/read"
+	"go-api/internal/http-server/handlers/order/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		status    string
+		limit     int
+		offset    int
+		respError string
+	}{
+		{
+			name:  "Defaults",
+			limit: 20,
+		},
+		{
+			name:   "Status page",
+			query:  "?status=paid&limit=5&offset=10",
+			status: storage.OrderPaid,
+			limit:  5,
+			offset: 10,
+		},
+		{
+			name:      "Invalid status",
+			query:     "?status=lost",
+			respError: "field Status is not valid",
+		},
+		{
+			name:      "Invalid limit",
+			query:     "?limit=many",
+			respError: "invalid request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			orderGetterMock := mocks.NewOrderGetter(t)
+
+			if tc.respError == "" {
+				orderGetterMock.On("ListOrders", tc.status, tc.limit, tc.offset).
+					Return([]storage.Order{{ID: 2, Status: storage.OrderPaid, Currency: "USD", Total: 500}}, nil).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/orders"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			read.NewList(slogdiscard.NewDiscardLogger(), orderGetterMock).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.ListResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Len(t, resp.Orders, 1)
+				require.Equal(t, "5.00", resp.Orders[0].Total)
+				require.Empty(t, resp.Orders[0].Items)
+			}
+		})
+	}
+}
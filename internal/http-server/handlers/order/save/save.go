@@ -0,0 +1,110 @@
+package save
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/order/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+// Request names the cart to order and optionally a promotion code.
+type Request struct {
+	CartId string `json:"cartId" validate:"required"`
+	Code   string `json:"code,omitempty" validate:"max=32"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=OrderPlacer
+type OrderPlacer interface {
+	PlaceOrder(cartID string, code string) (storage.Order, error)
+}
+
+// New places a pending order for the contents of the cart, discounted by
+// the promotion if a code is given. The cart is gone afterwards.
+func New(log *slog.Logger, orderPlacer OrderPlacer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.order.save.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		order, err := orderPlacer.PlaceOrder(req.CartId, req.Code)
+		if errors.Is(err, storage.ErrCartNotFound) {
+			log.Info("cart not found", slog.String("cart_id", req.CartId))
+
+			render.JSON(w, r, resp.Error(storage.ErrCartNotFound.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrCartEmpty) {
+			log.Info("cart is empty", slog.String("cart_id", req.CartId))
+
+			render.JSON(w, r, resp.Error(storage.ErrCartEmpty.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrPriceNotFound) || errors.Is(err, storage.ErrVariantNotFound) ||
+			errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("cart has a stale item", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrPromotionNotFound) || errors.Is(err, storage.ErrPromotionInactive) ||
+			errors.Is(err, storage.ErrPromotionUsedUp) || errors.Is(err, storage.ErrPromotionCurrency) ||
+			errors.Is(err, storage.ErrPromotionNoGoods) {
+			log.Info("promotion does not apply", slog.String("code", req.Code), sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to place order", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to place order"))
+
+			return
+		}
+
+		log.Info("order placed", slog.Int64("id", order.ID), slog.String("cart_id", req.CartId))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Order:    read.ToOrder(order),
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/order/read"
+	"go-api/internal/http-server/handlers/order/save"
+	"go-api/internal/http-server/handlers/order/save/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		code      string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			body: `{"cartId": "c1"}`,
+		},
+		{
+			name:      "Missing cart",
+			body:      `{}`,
+			respError: "field CartId is a required field",
+		},
+		{
+			name:      "Cart not found",
+			body:      `{"cartId": "c1"}`,
+			respError: storage.ErrCartNotFound.Error(),
+			mockError: storage.ErrCartNotFound,
+		},
+		{
+			name:      "Empty cart",
+			body:      `{"cartId": "c1"}`,
+			respError: storage.ErrCartEmpty.Error(),
+			mockError: storage.ErrCartEmpty,
+		},
+		{
+			name: "With promotion",
+			body: `{"cartId": "c1", "code": "spring10"}`,
+			code: "spring10",
+		},
+		{
+			name:      "Promotion used up",
+			body:      `{"cartId": "c1", "code": "spring10"}`,
+			code:      "spring10",
+			respError: storage.ErrPromotionUsedUp.Error(),
+			mockError: storage.ErrPromotionUsedUp,
+		},
+		{
+			name:      "Promotion not found",
+			body:      `{"cartId": "c1", "code": "nope"}`,
+			code:      "nope",
+			respError: storage.ErrPromotionNotFound.Error(),
+			mockError: storage.ErrPromotionNotFound,
+		},
+		{
+			name:      "PlaceOrder Error",
+			body:      `{"cartId": "c1"}`,
+			respError: "failed to place order",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			orderPlacerMock := mocks.NewOrderPlacer(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				orderPlacerMock.On("PlaceOrder", "c1", tc.code).
+					Return(storage.Order{
+						ID:       5,
+						Status:   storage.OrderPending,
+						Currency: "USD",
+						Total:    1900,
+						Weight:   200,
+						Items:    []storage.LineItem{{ID: 1, GoodsID: 7, Title: "Tea", Price: 950, Weight: 100, Quantity: 2}},
+					}, tc.mockError).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			save.New(slogdiscard.NewDiscardLogger(), orderPlacerMock).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "5", resp.Order.Id)
+				require.Equal(t, storage.OrderPending, resp.Order.Status)
+				require.Equal(t, "19.00", resp.Order.Total)
+				require.Len(t, resp.Order.Items, 1)
+			}
+		})
+	}
+}
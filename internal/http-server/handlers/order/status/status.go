@@ -0,0 +1,103 @@
+package status
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/order/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Status string `json:"status" validate:"required,oneof=pending paid shipped cancelled"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=OrderStatusSetter
+type OrderStatusSetter interface {
+	SetOrderStatus(id int64, status string) (storage.Order, error)
+}
+
+// New moves the order to another status. Pending orders may be paid or
+// cancelled, paid orders shipped or cancelled; shipped and cancelled
+// orders are final.
+func New(log *slog.Logger, orderStatusSetter OrderStatusSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.order.status.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid order id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		order, err := orderStatusSetter.SetOrderStatus(id, req.Status)
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			log.Info("order not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrOrderStatus) {
+			log.Info("order status change rejected", slog.Int64("id", id), sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to set order status", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("order status changed", slog.Int64("id", id), slog.String("status", order.Status))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Order:    read.ToOrder(order),
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package status_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/order/read"
+	"go-api/internal/http-server/handlers/order/status"
+	"go-api/internal/http-server/handlers/order/status/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestStatusHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		status    string
+		respError string
+		mockError error
+	}{
+		{
+			name:   "Success",
+			body:   `{"status": "paid"}`,
+			status: storage.OrderPaid,
+		},
+		{
+			name:      "Unknown status",
+			body:      `{"status": "lost"}`,
+			respError: "field Status is not valid",
+		},
+		{
+			name:      "Not allowed",
+			body:      `{"status": "pending"}`,
+			status:    storage.OrderPending,
+			respError: "order status change not allowed: shipped to pending",
+			mockError: fmt.Errorf("%w: shipped to pending", storage.ErrOrderStatus),
+		},
+		{
+			name:      "Not found",
+			body:      `{"status": "paid"}`,
+			status:    storage.OrderPaid,
+			respError: "not found",
+			mockError: storage.ErrOrderNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			orderStatusSetterMock := mocks.NewOrderStatusSetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				orderStatusSetterMock.On("SetOrderStatus", int64(5), tc.status).
+					Return(storage.Order{ID: 5, Status: tc.status, Currency: "USD"}, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Put("/orders/{id}/status", status.New(slogdiscard.NewDiscardLogger(), orderStatusSetterMock))
+
+			req, err := http.NewRequest(http.MethodPut, "/orders/5/status", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.status, resp.Order.Status)
+			}
+		})
+	}
+}
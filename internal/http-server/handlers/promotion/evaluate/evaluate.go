@@ -0,0 +1,186 @@
+package evaluate
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+// Request lists goods ids, repeated for several units of the same goods.
+type Request struct {
+	Code     string   `json:"code" validate:"required"`
+	Currency string   `json:"currency" validate:"required,len=3,uppercase"`
+	GoodsIds []string `json:"goodsIds" validate:"required,min=1,max=100,dive,number"`
+}
+
+type Item struct {
+	GoodsId  string `json:"goodsId"`
+	Title    string `json:"title"`
+	Quantity int64  `json:"quantity"`
+	Price    string `json:"price"`
+	Discount string `json:"discount"`
+	Total    string `json:"total"`
+}
+
+type Response struct {
+	resp.Response
+	Code     string `json:"code,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Items    []Item `json:"items,omitempty"`
+	Subtotal string `json:"subtotal,omitempty"`
+	Discount string `json:"discount,omitempty"`
+	Total    string `json:"total,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=PromotionEvaluator
+type PromotionEvaluator interface {
+	PriceGoods(goodsIDs []int64, currency string) ([]storage.LineItem, error)
+	ApplyPromotion(code string, currency string, items []storage.LineItem) (storage.Promotion, []int64, error)
+}
+
+// New computes the totals of the goods with the promotion applied. The
+// promotion is not used up; that happens when an order is placed with it.
+func New(log *slog.Logger, promotionEvaluator PromotionEvaluator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.promotion.evaluate.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		ids := make([]int64, 0, len(req.GoodsIds))
+		for _, v := range req.GoodsIds {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				log.Info("invalid goods id", sl.Err(err))
+
+				render.JSON(w, r, resp.Error("invalid request"))
+
+				return
+			}
+
+			ids = append(ids, id)
+		}
+
+		items, err := promotionEvaluator.PriceGoods(ids, req.Currency)
+		if errors.Is(err, storage.ErrGoodsNotFound) || errors.Is(err, storage.ErrPriceNotFound) {
+			log.Info("goods cannot be priced", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to price goods", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		promotion, discounts, err := promotionEvaluator.ApplyPromotion(req.Code, req.Currency, items)
+		if isPromotionErr(err) {
+			log.Info("promotion does not apply", slog.String("code", req.Code), sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to apply promotion", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("promotion evaluated", slog.String("code", promotion.Code), slog.Int("items", len(items)))
+
+		render.JSON(w, r, ToResponse(promotion.Code, req.Currency, items, discounts))
+	}
+}
+
+// ToResponse builds the response from the priced lines and the discount
+// on each of them.
+func ToResponse(code string, currency string, items []storage.LineItem, discounts []int64) Response {
+	res := Response{
+		Response: resp.OK(),
+		Code:     code,
+		Currency: currency,
+		Items:    make([]Item, 0, len(items)),
+	}
+
+	var subtotal, discount int64
+
+	for i, item := range items {
+		amount := item.Price * item.Quantity
+
+		res.Items = append(res.Items, Item{
+			GoodsId:  strconv.FormatInt(item.GoodsID, 10),
+			Title:    item.Title,
+			Quantity: item.Quantity,
+			Price:    money.Format(item.Price, currency),
+			Discount: money.Format(discounts[i], currency),
+			Total:    money.Format(amount-discounts[i], currency),
+		})
+
+		subtotal += amount
+		discount += discounts[i]
+	}
+
+	res.Subtotal = money.Format(subtotal, currency)
+	res.Discount = money.Format(discount, currency)
+	res.Total = money.Format(subtotal-discount, currency)
+
+	return res
+}
+
+func isPromotionErr(err error) bool {
+	for _, target := range []error{
+		storage.ErrPromotionNotFound,
+		storage.ErrPromotionInactive,
+		storage.ErrPromotionUsedUp,
+		storage.ErrPromotionCurrency,
+		storage.ErrPromotionNoGoods,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
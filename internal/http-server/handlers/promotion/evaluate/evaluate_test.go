@@ -0,0 +1,109 @@
+package evaluate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/promotion/evaluate"
+	"go-api/internal/http-server/handlers/promotion/evaluate/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var items = []storage.LineItem{
+	{GoodsID: 7, Title: "Tea", Price: 950, Quantity: 2},
+	{GoodsID: 8, Title: "Cup", Price: 400, Quantity: 1},
+}
+
+func TestEvaluateHandler(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		priceError error
+		respError  string
+		mockError  error
+	}{
+		{
+			name: "Success",
+			body: `{"code": "tea10", "currency": "USD", "goodsIds": ["7", "8", "7"]}`,
+		},
+		{
+			name:      "Missing goods",
+			body:      `{"code": "tea10", "currency": "USD"}`,
+			respError: "field GoodsIds is a required field",
+		},
+		{
+			name:      "Invalid goods id",
+			body:      `{"code": "tea10", "currency": "USD", "goodsIds": ["x"]}`,
+			respError: "field GoodsIds[0] is not valid",
+		},
+		{
+			name:       "Goods not found",
+			body:       `{"code": "tea10", "currency": "USD", "goodsIds": ["7", "8", "7"]}`,
+			priceError: storage.ErrGoodsNotFound,
+			respError:  storage.ErrGoodsNotFound.Error(),
+		},
+		{
+			name:      "Inactive",
+			body:      `{"code": "tea10", "currency": "USD", "goodsIds": ["7", "8", "7"]}`,
+			respError: storage.ErrPromotionInactive.Error(),
+			mockError: storage.ErrPromotionInactive,
+		},
+		{
+			name:      "ApplyPromotion Error",
+			body:      `{"code": "tea10", "currency": "USD", "goodsIds": ["7", "8", "7"]}`,
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			promotionEvaluatorMock := mocks.NewPromotionEvaluator(t)
+
+			if tc.respError == "" || tc.priceError != nil || tc.mockError != nil {
+				promotionEvaluatorMock.On("PriceGoods", []int64{7, 8, 7}, "USD").
+					Return(items, tc.priceError).Once()
+			}
+
+			if tc.respError == "" || tc.mockError != nil {
+				promotionEvaluatorMock.On("ApplyPromotion", "tea10", "USD", items).
+					Return(storage.Promotion{Code: "TEA10"}, []int64{190, 0}, tc.mockError).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/promotions/evaluate", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			evaluate.New(slogdiscard.NewDiscardLogger(), promotionEvaluatorMock).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp evaluate.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "TEA10", resp.Code)
+				require.Equal(t, "23.00", resp.Subtotal)
+				require.Equal(t, "1.90", resp.Discount)
+				require.Equal(t, "21.10", resp.Total)
+				require.Len(t, resp.Items, 2)
+				require.Equal(t, "17.10", resp.Items[0].Total)
+				require.Equal(t, "0.00", resp.Items[1].Discount)
+			}
+		})
+	}
+}
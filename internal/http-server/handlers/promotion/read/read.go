@@ -0,0 +1,194 @@
+package read
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+// Promotion is the JSON representation of a promotion. Percent is set for
+// percentage discounts, Amount and Currency for fixed-amount ones.
+type Promotion struct {
+	Id          string     `json:"id"`
+	Code        string     `json:"code"`
+	Kind        string     `json:"kind"`
+	Percent     int64      `json:"percent,omitempty"`
+	Amount      string     `json:"amount,omitempty"`
+	Currency    string     `json:"currency,omitempty"`
+	StartsAt    *time.Time `json:"startsAt,omitempty"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+	UsageLimit  int64      `json:"usageLimit,omitempty"`
+	Used        int64      `json:"used"`
+	CategoryIds []string   `json:"categoryIds,omitempty"`
+	GoodsIds    []string   `json:"goodsIds,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type Response struct {
+	resp.Response
+	Promotion
+}
+
+type ListResponse struct {
+	resp.Response
+	Promotions []Promotion `json:"promotions"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=PromotionGetter
+type PromotionGetter interface {
+	GetPromotion(code string) (storage.Promotion, error)
+	ListPromotions(limit int, offset int) ([]storage.Promotion, error)
+}
+
+func New(log *slog.Logger, promotionGetter PromotionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.promotion.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		code := chi.URLParam(r, "code")
+
+		promotion, err := promotionGetter.GetPromotion(code)
+		if errors.Is(err, storage.ErrPromotionNotFound) {
+			log.Info("promotion not found", slog.String("code", code))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get promotion", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got promotion", slog.Int64("id", promotion.ID))
+
+		render.JSON(w, r, Response{
+			Response:  resp.OK(),
+			Promotion: ToPromotion(promotion),
+		})
+	}
+}
+
+// NewList lists promotions, newest first.
+func NewList(log *slog.Logger, promotionGetter PromotionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.promotion.read.NewList"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		promotions, err := promotionGetter.ListPromotions(limit, offset)
+		if err != nil {
+			log.Error("failed to list promotions", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed promotions", slog.Int("count", len(promotions)))
+
+		res := ListResponse{
+			Response:   resp.OK(),
+			Promotions: make([]Promotion, 0, len(promotions)),
+		}
+
+		for _, p := range promotions {
+			res.Promotions = append(res.Promotions, ToPromotion(p))
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
+
+// ToPromotion converts storage.Promotion to its JSON representation.
+func ToPromotion(p storage.Promotion) Promotion {
+	res := Promotion{
+		Id:         strconv.FormatInt(p.ID, 10),
+		Code:       p.Code,
+		Kind:       p.Kind,
+		UsageLimit: p.UsageLimit,
+		Used:       p.Used,
+		CreatedAt:  p.CreatedAt,
+	}
+
+	if p.Kind == storage.PromotionFixed {
+		res.Amount = money.Format(p.Value, p.Currency)
+		res.Currency = p.Currency
+	} else {
+		res.Percent = p.Value
+	}
+
+	if !p.StartsAt.IsZero() {
+		res.StartsAt = &p.StartsAt
+	}
+	if !p.EndsAt.IsZero() {
+		res.EndsAt = &p.EndsAt
+	}
+
+	for _, id := range p.CategoryIDs {
+		res.CategoryIds = append(res.CategoryIds, strconv.FormatInt(id, 10))
+	}
+	for _, id := range p.GoodsIDs {
+		res.GoodsIds = append(res.GoodsIds, strconv.FormatInt(id, 10))
+	}
+
+	return res
+}
@@ -0,0 +1,95 @@
+package read_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/promotion/read"
+	"go-api/internal/http-server/handlers/promotion/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestReadHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		code      string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			code: "tea5",
+		},
+		{
+			name:      "Not found",
+			code:      "nope",
+			respError: "not found",
+			mockError: storage.ErrPromotionNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			promotionGetterMock := mocks.NewPromotionGetter(t)
+
+			promotionGetterMock.On("GetPromotion", tc.code).
+				Return(storage.Promotion{ID: 3, Code: "TEA5", Kind: storage.PromotionFixed, Value: 500, Currency: "USD", GoodsIDs: []int64{7}}, tc.mockError).Once()
+
+			r := chi.NewRouter()
+			r.Get("/promotions/{code}", read.New(slogdiscard.NewDiscardLogger(), promotionGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, "/promotions/"+tc.code, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "TEA5", resp.Code)
+				require.Equal(t, "5.00", resp.Amount)
+				require.Zero(t, resp.Percent)
+				require.Equal(t, []string{"7"}, resp.GoodsIds)
+				require.Nil(t, resp.EndsAt)
+			}
+		})
+	}
+}
+
+func TestListHandler(t *testing.T) {
+	promotionGetterMock := mocks.NewPromotionGetter(t)
+
+	promotionGetterMock.On("ListPromotions", 10, 0).
+		Return([]storage.Promotion{{ID: 1, Code: "SPRING10", Kind: storage.PromotionPercent, Value: 10, Used: 4}}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, "/promotions?limit=10", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	read.NewList(slogdiscard.NewDiscardLogger(), promotionGetterMock).ServeHTTP(rr, req)
+
+	var resp read.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+	require.Len(t, resp.Promotions, 1)
+	require.Equal(t, int64(10), resp.Promotions[0].Percent)
+	require.Equal(t, int64(4), resp.Promotions[0].Used)
+}
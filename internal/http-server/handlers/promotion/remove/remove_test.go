@@ -0,0 +1,74 @@
+package remove_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/promotion/remove"
+	"go-api/internal/http-server/handlers/promotion/remove/mocks"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestRemoveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		id        string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+			id:   "1",
+		},
+		{
+			name:      "Invalid id",
+			id:        "abc",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not found",
+			id:        "1",
+			respError: "not found",
+			mockError: storage.ErrPromotionNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			promotionRemoverMock := mocks.NewPromotionRemover(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				promotionRemoverMock.On("DeletePromotion", int64(1)).
+					Return(tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Delete("/promotions/{id}", remove.New(slogdiscard.NewDiscardLogger(), promotionRemoverMock))
+
+			req, err := http.NewRequest(http.MethodDelete, "/promotions/"+tc.id, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var res resp.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+			require.Equal(t, tc.respError, res.Error)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package save
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/promotion/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Code        string     `json:"code" validate:"required"`
+	Kind        string     `json:"kind" validate:"required,oneof=percent fixed"`
+	Percent     int64      `json:"percent,omitempty" validate:"required_if=Kind percent,omitempty,min=1,max=100"`
+	Amount      string     `json:"amount,omitempty" validate:"required_if=Kind fixed"`
+	Currency    string     `json:"currency,omitempty" validate:"required_if=Kind fixed,omitempty,len=3,uppercase"`
+	StartsAt    *time.Time `json:"startsAt,omitempty"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+	UsageLimit  int64      `json:"usageLimit,omitempty" validate:"min=0"`
+	CategoryIds []string   `json:"categoryIds,omitempty" validate:"max=100,dive,number"`
+	GoodsIds    []string   `json:"goodsIds,omitempty" validate:"max=100,dive,number"`
+}
+
+var (
+	ErrInvalidCode   = errors.New("code must be 3 to 32 letters, digits, dashes or underscores")
+	ErrInvalidAmount = errors.New("failed parse amount value")
+	ErrInvalidWindow = errors.New("endsAt must be after startsAt")
+)
+
+var codePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{3,32}$`)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=PromotionSaver
+type PromotionSaver interface {
+	SavePromotion(promotion storage.Promotion) (int64, error)
+}
+
+func New(log *slog.Logger, promotionSaver PromotionSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.promotion.save.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		promotion, err := Validate(req)
+		if err != nil {
+			log.Info("invalid request", sl.Err(err))
+
+			var validateErr validator.ValidationErrors
+			if errors.As(err, &validateErr) {
+				render.JSON(w, r, resp.ValidationError(validateErr))
+			} else {
+				render.JSON(w, r, resp.Error(err.Error()))
+			}
+
+			return
+		}
+
+		id, err := promotionSaver.SavePromotion(promotion)
+		if errors.Is(err, storage.ErrPromotionExists) {
+			log.Info("promotion already exists", slog.String("code", promotion.Code))
+
+			render.JSON(w, r, resp.Error(storage.ErrPromotionExists.Error()))
+
+			return
+		}
+		if errors.Is(err, storage.ErrCategoryNotFound) || errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("promotion scope not found", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to add promotion", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to add promotion"))
+
+			return
+		}
+
+		log.Info("promotion added", slog.Int64("id", id), slog.String("code", promotion.Code))
+
+		promotion.ID = id
+		promotion.CreatedAt = time.Now().UTC()
+
+		render.JSON(w, r, read.Response{
+			Response:  resp.OK(),
+			Promotion: read.ToPromotion(promotion),
+		})
+	}
+}
+
+// Validate checks req and converts it to storage.Promotion. Besides
+// validator.ValidationErrors it may return ErrInvalidCode, ErrInvalidWindow
+// or ErrInvalidAmount wrapping a money error; all of them are fit for the
+// client.
+func Validate(req Request) (storage.Promotion, error) {
+	if err := validator.New().Struct(req); err != nil {
+		return storage.Promotion{}, err
+	}
+
+	if !codePattern.MatchString(req.Code) {
+		return storage.Promotion{}, ErrInvalidCode
+	}
+
+	promotion := storage.Promotion{
+		Code:       req.Code,
+		Kind:       req.Kind,
+		Value:      req.Percent,
+		UsageLimit: req.UsageLimit,
+	}
+
+	if req.Kind == storage.PromotionFixed {
+		amount, err := money.Parse(req.Amount, req.Currency)
+		if err != nil {
+			return storage.Promotion{}, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+		}
+		if amount <= 0 {
+			return storage.Promotion{}, fmt.Errorf("%w: not positive", ErrInvalidAmount)
+		}
+
+		promotion.Value = amount
+		promotion.Currency = req.Currency
+	}
+
+	if req.StartsAt != nil {
+		promotion.StartsAt = req.StartsAt.UTC()
+	}
+	if req.EndsAt != nil {
+		promotion.EndsAt = req.EndsAt.UTC()
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return storage.Promotion{}, ErrInvalidWindow
+	}
+
+	for _, id := range req.CategoryIds {
+		categoryID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return storage.Promotion{}, fmt.Errorf("%w: %s", storage.ErrCategoryNotFound, id)
+		}
+
+		promotion.CategoryIDs = append(promotion.CategoryIDs, categoryID)
+	}
+
+	for _, id := range req.GoodsIds {
+		goodsID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return storage.Promotion{}, fmt.Errorf("%w: %s", storage.ErrGoodsNotFound, id)
+		}
+
+		promotion.GoodsIDs = append(promotion.GoodsIDs, goodsID)
+	}
+
+	return promotion, nil
+}
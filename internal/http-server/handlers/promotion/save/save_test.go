@@ -0,0 +1,129 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/promotion/read"
+	"go-api/internal/http-server/handlers/promotion/save"
+	"go-api/internal/http-server/handlers/promotion/save/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		promotion storage.Promotion
+		respError string
+		mockError error
+	}{
+		{
+			name:      "Percent",
+			body:      `{"code": "SPRING10", "kind": "percent", "percent": 10, "usageLimit": 100}`,
+			promotion: storage.Promotion{Code: "SPRING10", Kind: storage.PromotionPercent, Value: 10, UsageLimit: 100},
+		},
+		{
+			name: "Fixed with scope and window",
+			body: `{"code": "TEA5", "kind": "fixed", "amount": "5", "currency": "USD", "goodsIds": ["7"], "categoryIds": ["2"],
+				"startsAt": "2024-01-01T00:00:00Z", "endsAt": "2024-02-01T00:00:00Z"}`,
+			promotion: storage.Promotion{
+				Code: "TEA5", Kind: storage.PromotionFixed, Value: 500, Currency: "USD",
+				StartsAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndsAt:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				CategoryIDs: []int64{2}, GoodsIDs: []int64{7},
+			},
+		},
+		{
+			name:      "Missing percent",
+			body:      `{"code": "SPRING10", "kind": "percent"}`,
+			respError: "field Percent is a required field",
+		},
+		{
+			name:      "Percent over 100",
+			body:      `{"code": "SPRING10", "kind": "percent", "percent": 150}`,
+			respError: "field Percent is not valid",
+		},
+		{
+			name:      "Missing currency",
+			body:      `{"code": "TEA5", "kind": "fixed", "amount": "5"}`,
+			respError: "field Currency is a required field",
+		},
+		{
+			name:      "Unknown kind",
+			body:      `{"code": "TEA5", "kind": "bogo"}`,
+			respError: "field Kind is not valid",
+		},
+		{
+			name:      "Invalid code",
+			body:      `{"code": "a b", "kind": "percent", "percent": 5}`,
+			respError: save.ErrInvalidCode.Error(),
+		},
+		{
+			name:      "Zero amount",
+			body:      `{"code": "TEA0", "kind": "fixed", "amount": "0", "currency": "USD"}`,
+			respError: "failed parse amount value: not positive",
+		},
+		{
+			name:      "Ends before start",
+			body:      `{"code": "LATE", "kind": "percent", "percent": 5, "startsAt": "2024-02-01T00:00:00Z", "endsAt": "2024-01-01T00:00:00Z"}`,
+			respError: save.ErrInvalidWindow.Error(),
+		},
+		{
+			name:      "Exists",
+			body:      `{"code": "SPRING10", "kind": "percent", "percent": 10}`,
+			promotion: storage.Promotion{Code: "SPRING10", Kind: storage.PromotionPercent, Value: 10},
+			respError: storage.ErrPromotionExists.Error(),
+			mockError: storage.ErrPromotionExists,
+		},
+		{
+			name:      "SavePromotion Error",
+			body:      `{"code": "SPRING10", "kind": "percent", "percent": 10}`,
+			promotion: storage.Promotion{Code: "SPRING10", Kind: storage.PromotionPercent, Value: 10},
+			respError: "failed to add promotion",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			promotionSaverMock := mocks.NewPromotionSaver(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				promotionSaverMock.On("SavePromotion", tc.promotion).
+					Return(int64(1), tc.mockError).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/promotions", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			save.New(slogdiscard.NewDiscardLogger(), promotionSaverMock).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "1", resp.Id)
+				require.Equal(t, tc.promotion.Code, resp.Code)
+			}
+		})
+	}
+}
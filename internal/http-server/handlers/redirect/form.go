@@ -0,0 +1,38 @@
+package redirect
+
+import (
+	"html/template"
+	"net/http"
+)
+
+var passwordForm = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+</head>
+<body>
+<form method="post" action="/{{.Alias}}">
+<p>This link is password protected.</p>
+{{with .Error}}<p role="alert">{{.}}</p>{{end}}
+<input type="password" name="password" autocomplete="current-password" required autofocus>
+<button type="submit">Open</button>
+</form>
+</body>
+</html>
+`))
+
+// renderForm writes the password form of a protected link with status
+// code, and the reason the password was not accepted, if any.
+func renderForm(w http.ResponseWriter, code int, alias string, reason string) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	return passwordForm.Execute(w, struct {
+		Alias string
+		Error string
+	}{alias, reason})
+}
@@ -2,6 +2,7 @@ package redirect
 
 import (
 	"errors"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -9,7 +10,11 @@ import (
 	"go-api/internal/lib/logger/sl"
 	"go-api/internal/storage"
 	"log/slog"
+	"math"
 	"net/http"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLGetter
@@ -17,7 +22,25 @@ type URLGetter interface {
 	GetURL(alias string) (string, error)
 }
 
-func New(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLUnlocker
+type URLUnlocker interface {
+	GetURLPasswordHash(alias string) (string, error)
+	UnlockURL(alias string) (string, error)
+}
+
+// PasswordLimiter throttles password guesses; it is implemented by
+// throttle.Limiter.
+type PasswordLimiter interface {
+	Reserve(key string, now time.Time) time.Duration
+	Reset(key string)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ClickRecorder
+type ClickRecorder interface {
+	Record(alias string, r *http.Request)
+}
+
+func New(log *slog.Logger, urlGetter URLGetter, clickRecorder ClickRecorder) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.url.redirect.New"
 
@@ -43,6 +66,23 @@ func New(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, storage.ErrURLProtected) {
+			log.Info("url is password protected", "alias", alias)
+
+			if err := renderForm(w, http.StatusOK, alias, ""); err != nil {
+				log.Error("failed to render password form", sl.Err(err))
+			}
+
+			return
+		}
+		if errors.Is(err, storage.ErrURLExpired) {
+			log.Info("url expired", "alias", alias)
+
+			render.Status(r, http.StatusGone)
+			render.JSON(w, r, resp.Error("url expired"))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed to get url", sl.Err(err))
 
@@ -53,7 +93,123 @@ func New(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
 
 		log.Info("got url", slog.String("url", resURL))
 
+		// recorded in the background, so it does not delay the redirect
+		clickRecorder.Record(alias, r)
+
 		// redirect to found url
 		http.Redirect(w, r, resURL, http.StatusFound)
 	}
 }
+
+// NewUnlock checks the password posted by the form of a protected link
+// and redirects if it matches. Only the attempts on protected links are
+// throttled, so that made-up aliases don't fill the limiter. Every attempt
+// counts as a wrong password until it matches, so that concurrent guesses
+// can't outrun the limiter; once an alias had too many, the form is
+// refused until the limiter lets it be tried again.
+func NewUnlock(
+	log *slog.Logger,
+	urlUnlocker URLUnlocker,
+	clickRecorder ClickRecorder,
+	passwordLimiter PasswordLimiter,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.redirect.NewUnlock"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias := chi.URLParam(r, "alias")
+		if alias == "" {
+			log.Info("alias is empty")
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		hash, err := urlUnlocker.GetURLPasswordHash(alias)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Info("url not found", "alias", alias)
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrURLExpired) {
+			log.Info("url expired", "alias", alias)
+
+			render.Status(r, http.StatusGone)
+			render.JSON(w, r, resp.Error("url expired"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get url password", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		if hash != "" {
+			if wait := passwordLimiter.Reserve(alias, time.Now()); wait > 0 {
+				log.Warn("too many wrong passwords", slog.String("alias", alias))
+
+				w.Header().Set("Retry-After", fmt.Sprint(math.Ceil(wait.Seconds())))
+
+				if err := renderForm(w, http.StatusTooManyRequests, alias, "Too many attempts, try again later."); err != nil {
+					log.Error("failed to render password form", sl.Err(err))
+				}
+
+				return
+			}
+
+			err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(r.PostFormValue("password")))
+			if err != nil {
+				log.Info("wrong password", slog.String("alias", alias))
+
+				if err := renderForm(w, http.StatusUnauthorized, alias, "Wrong password."); err != nil {
+					log.Error("failed to render password form", sl.Err(err))
+				}
+
+				return
+			}
+
+			passwordLimiter.Reset(alias)
+		}
+
+		resURL, err := urlUnlocker.UnlockURL(alias)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Info("url not found", "alias", alias)
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrURLExpired) {
+			log.Info("url expired", "alias", alias)
+
+			render.Status(r, http.StatusGone)
+			render.JSON(w, r, resp.Error("url expired"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to unlock url", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("url unlocked", slog.String("url", resURL))
+
+		clickRecorder.Record(alias, r)
+
+		// see other, as the form was posted
+		http.Redirect(w, r, resURL, http.StatusSeeOther)
+	}
+}
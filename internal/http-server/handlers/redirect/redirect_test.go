@@ -1,15 +1,26 @@
 package redirect_test
 
 import (
+	"encoding/json"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go-api/internal/http-server/handlers/redirect"
 	"go-api/internal/http-server/handlers/redirect/mocks"
 	"go-api/internal/lib/api"
+	resp "go-api/internal/lib/api/response"
 	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/lib/throttle"
+	"go-api/internal/storage"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -30,14 +41,19 @@ func TestSaveHandler(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			urlGetterMock := mocks.NewURLGetter(t)
+			clickRecorderMock := mocks.NewClickRecorder(t)
 
 			if tc.respError == "" || tc.mockError != nil {
 				urlGetterMock.On("GetURL", tc.alias).
 					Return(tc.url, tc.mockError).Once()
 			}
+			if tc.respError == "" {
+				clickRecorderMock.On("Record", tc.alias, mock.AnythingOfType("*http.Request")).
+					Return().Once()
+			}
 
 			r := chi.NewRouter()
-			r.Get("/{alias}", redirect.New(slogdiscard.NewDiscardLogger(), urlGetterMock))
+			r.Get("/{alias}", redirect.New(slogdiscard.NewDiscardLogger(), urlGetterMock, clickRecorderMock))
 
 			ts := httptest.NewServer(r)
 			defer ts.Close()
@@ -50,3 +66,220 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		code      int
+		respError string
+		mockError error
+	}{
+		{
+			name:      "Expired",
+			code:      http.StatusGone,
+			respError: "url expired",
+			mockError: storage.ErrURLExpired,
+		},
+		{
+			name:      "Not found",
+			code:      http.StatusOK,
+			respError: "not found",
+			mockError: storage.ErrURLNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlGetterMock := mocks.NewURLGetter(t)
+			clickRecorderMock := mocks.NewClickRecorder(t)
+
+			urlGetterMock.On("GetURL", "test_alias").
+				Return("", tc.mockError).Once()
+
+			r := chi.NewRouter()
+			r.Get("/{alias}", redirect.New(slogdiscard.NewDiscardLogger(), urlGetterMock, clickRecorderMock))
+
+			req := httptest.NewRequest(http.MethodGet, "/test_alias", nil)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.code, rr.Code)
+
+			var res resp.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+			require.Equal(t, tc.respError, res.Error)
+		})
+	}
+}
+
+func TestProtectedHandler(t *testing.T) {
+	urlGetterMock := mocks.NewURLGetter(t)
+	clickRecorderMock := mocks.NewClickRecorder(t)
+
+	urlGetterMock.On("GetURL", "test_alias").
+		Return("", storage.ErrURLProtected).Once()
+
+	r := chi.NewRouter()
+	r.Get("/{alias}", redirect.New(slogdiscard.NewDiscardLogger(), urlGetterMock, clickRecorderMock))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test_alias", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+	require.Contains(t, rr.Body.String(), `action="/test_alias"`)
+	require.Contains(t, rr.Body.String(), `name="password"`)
+}
+
+func TestUnlockHandler(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		password  string
+		failures  int
+		hashError error
+		code      int
+		unlock    bool
+	}{
+		{
+			name:     "Success",
+			password: "s3cret",
+			code:     http.StatusSeeOther,
+			unlock:   true,
+		},
+		{
+			name:     "After failures",
+			password: "s3cret",
+			failures: 2,
+			code:     http.StatusSeeOther,
+			unlock:   true,
+		},
+		{
+			name:     "Wrong password",
+			password: "guess",
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "Throttled",
+			password: "s3cret",
+			failures: 3,
+			code:     http.StatusTooManyRequests,
+		},
+		{
+			name:      "Expired",
+			password:  "s3cret",
+			hashError: storage.ErrURLExpired,
+			code:      http.StatusGone,
+		},
+		{
+			name:      "Unknown alias",
+			password:  "guess",
+			failures:  2,
+			hashError: storage.ErrURLNotFound,
+			code:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlUnlockerMock := mocks.NewURLUnlocker(t)
+			clickRecorderMock := mocks.NewClickRecorder(t)
+			limiter := throttle.New(3, time.Minute)
+
+			for i := 0; i < tc.failures; i++ {
+				limiter.Fail("test_alias", time.Now())
+			}
+
+			urlUnlockerMock.On("GetURLPasswordHash", "test_alias").
+				Return(string(hash), tc.hashError).Once()
+			if tc.unlock {
+				urlUnlockerMock.On("UnlockURL", "test_alias").
+					Return("https://www.google.com/", nil).Once()
+				clickRecorderMock.On("Record", "test_alias", mock.AnythingOfType("*http.Request")).
+					Return().Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/{alias}", redirect.NewUnlock(slogdiscard.NewDiscardLogger(), urlUnlockerMock, clickRecorderMock, limiter))
+
+			form := url.Values{"password": {tc.password}}
+
+			req := httptest.NewRequest(http.MethodPost, "/test_alias", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.code, rr.Code)
+
+			switch tc.code {
+			case http.StatusSeeOther:
+				require.Equal(t, "https://www.google.com/", rr.Header().Get("Location"))
+				require.Zero(t, limiter.Wait("test_alias", time.Now()))
+			case http.StatusUnauthorized:
+				require.Contains(t, rr.Body.String(), "Wrong password.")
+			case http.StatusTooManyRequests:
+				require.Equal(t, "60", rr.Header().Get("Retry-After"))
+			}
+
+			if tc.hashError != nil {
+				require.Zero(t, limiter.Wait("test_alias", time.Now()), "attempts on missing urls are not counted")
+			}
+		})
+	}
+}
+
+func TestUnlockHandlerConcurrentGuesses(t *testing.T) {
+	const guesses = 10
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	urlUnlockerMock := mocks.NewURLUnlocker(t)
+	urlUnlockerMock.On("GetURLPasswordHash", "test_alias").
+		Return(string(hash), nil)
+
+	r := chi.NewRouter()
+	r.Post("/{alias}", redirect.NewUnlock(slogdiscard.NewDiscardLogger(), urlUnlockerMock, mocks.NewClickRecorder(t), throttle.New(3, time.Minute)))
+
+	var (
+		wg    sync.WaitGroup
+		codes = make([]int, guesses)
+	)
+
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			form := url.Values{"password": {"guess"}}
+
+			req := httptest.NewRequest(http.MethodPost, "/test_alias", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			codes[i] = rr.Code
+		}(i)
+	}
+
+	wg.Wait()
+
+	counts := make(map[int]int)
+	for _, code := range codes {
+		counts[code]++
+	}
+
+	require.Equal(t, map[int]int{http.StatusUnauthorized: 3, http.StatusTooManyRequests: guesses - 3}, counts)
+}
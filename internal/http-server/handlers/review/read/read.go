@@ -0,0 +1,226 @@
+package read
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+type Review struct {
+	Id        string    `json:"id"`
+	GoodsId   string    `json:"goodsId"`
+	Author    string    `json:"author"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Response nests the review, as its status would clash with the status of
+// the response.
+type Response struct {
+	resp.Response
+	Review Review `json:"review"`
+}
+
+type ListRequest struct {
+	Status string `validate:"omitempty,oneof=pending approved rejected"`
+	Limit  int    `validate:"min=0,max=100"`
+	Offset int    `validate:"min=0"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Reviews []Review `json:"reviews"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ReviewGetter
+type ReviewGetter interface {
+	ListReviews(goodsID int64, status string, limit int, offset int) ([]storage.Review, error)
+}
+
+// New lists the approved reviews of goods, newest first.
+func New(log *slog.Logger, reviewGetter ReviewGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.read.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		goodsID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("failed to parse query", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		reviews, err := reviewGetter.ListReviews(goodsID, storage.ReviewApproved, limit, offset)
+		if err != nil {
+			log.Error("failed to list reviews", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed reviews", slog.Int64("goods_id", goodsID), slog.Int("count", len(reviews)))
+
+		render.JSON(w, r, ListResponse{
+			Response: resp.OK(),
+			Reviews:  ToReviews(reviews),
+		})
+	}
+}
+
+// NewList lists the reviews of all goods for moderation, optionally only
+// those in one status.
+func NewList(log *slog.Logger, reviewGetter ReviewGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.read.NewList"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		req, err := parseListRequest(r)
+		if err != nil {
+			log.Info("failed to parse query", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultLimit
+		}
+
+		reviews, err := reviewGetter.ListReviews(0, req.Status, limit, req.Offset)
+		if err != nil {
+			log.Error("failed to list reviews", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed reviews", slog.Int("count", len(reviews)))
+
+		render.JSON(w, r, ListResponse{
+			Response: resp.OK(),
+			Reviews:  ToReviews(reviews),
+		})
+	}
+}
+
+func parseListRequest(r *http.Request) (ListRequest, error) {
+	var (
+		req ListRequest
+		err error
+	)
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	req.Status = q.Get("status")
+
+	return req, nil
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
+
+// ToReviews converts reviews to their JSON representation.
+func ToReviews(reviews []storage.Review) []Review {
+	res := make([]Review, 0, len(reviews))
+
+	for _, review := range reviews {
+		res = append(res, ToReview(review))
+	}
+
+	return res
+}
+
+func ToReview(review storage.Review) Review {
+	return Review{
+		Id:        strconv.FormatInt(review.ID, 10),
+		GoodsId:   strconv.FormatInt(review.GoodsID, 10),
+		Author:    review.Author,
+		Rating:    review.Rating,
+		Text:      review.Text,
+		Status:    review.Status,
+		CreatedAt: review.CreatedAt,
+	}
+}
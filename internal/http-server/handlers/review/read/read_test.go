@@ -0,0 +1,166 @@
+package read_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/review/read"
+	"go-api/internal/http-server/handlers/review/read/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var reviews = []storage.Review{
+	{ID: 2, GoodsID: 7, Author: "bob", Rating: 5, Status: storage.ReviewApproved},
+	{ID: 1, GoodsID: 7, Author: "ann", Rating: 4, Text: "Fine tea", Status: storage.ReviewApproved},
+}
+
+func TestReadHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		path      string
+		limit     int
+		offset    int
+		respError string
+		mockError error
+	}{
+		{
+			name:  "Defaults",
+			path:  "/goods/7/reviews",
+			limit: 20,
+		},
+		{
+			name:   "Page",
+			path:   "/goods/7/reviews?limit=2&offset=4",
+			limit:  2,
+			offset: 4,
+		},
+		{
+			name:      "Invalid limit",
+			path:      "/goods/7/reviews?limit=0",
+			respError: "invalid request",
+		},
+		{
+			name:      "Invalid goods id",
+			path:      "/goods/tea/reviews",
+			respError: "invalid request",
+		},
+		{
+			name:      "ListReviews Error",
+			path:      "/goods/7/reviews",
+			limit:     20,
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewGetterMock := mocks.NewReviewGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewGetterMock.On("ListReviews", int64(7), storage.ReviewApproved, tc.limit, tc.offset).
+					Return(reviews, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Get("/goods/{id}/reviews", read.New(slogdiscard.NewDiscardLogger(), reviewGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.ListResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Len(t, resp.Reviews, 2)
+				require.Equal(t, "2", resp.Reviews[0].Id)
+				require.Equal(t, "Fine tea", resp.Reviews[1].Text)
+			}
+		})
+	}
+}
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		status    string
+		limit     int
+		offset    int
+		respError string
+	}{
+		{
+			name:  "Defaults",
+			limit: 20,
+		},
+		{
+			name:   "Pending",
+			query:  "?status=pending&limit=5&offset=10",
+			status: storage.ReviewPending,
+			limit:  5,
+			offset: 10,
+		},
+		{
+			name:      "Unknown status",
+			query:     "?status=spam",
+			respError: "field Status is not valid",
+		},
+		{
+			name:      "Invalid limit",
+			query:     "?limit=many",
+			respError: "invalid request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewGetterMock := mocks.NewReviewGetter(t)
+
+			if tc.respError == "" {
+				reviewGetterMock.On("ListReviews", int64(0), tc.status, tc.limit, tc.offset).
+					Return(reviews, nil).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/reviews"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			read.NewList(slogdiscard.NewDiscardLogger(), reviewGetterMock).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.ListResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Len(t, resp.Reviews, 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package save
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/review/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Author string `json:"author" validate:"required,max=64"`
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Text   string `json:"text,omitempty" validate:"max=2000"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ReviewSaver
+type ReviewSaver interface {
+	SaveReview(review storage.Review) (storage.Review, error)
+}
+
+// New submits a review of goods. The review is pending until a moderator
+// approves it.
+func New(log *slog.Logger, reviewSaver ReviewSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.save.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		goodsID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid goods id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		review, err := reviewSaver.SaveReview(storage.Review{
+			GoodsID: goodsID,
+			Author:  req.Author,
+			Rating:  req.Rating,
+			Text:    req.Text,
+		})
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			log.Info("goods not found", slog.Int64("goods_id", goodsID))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to save review", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("review saved", slog.Int64("id", review.ID), slog.Int64("goods_id", goodsID))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Review:   read.ToReview(review),
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/review/read"
+	"go-api/internal/http-server/handlers/review/save"
+	"go-api/internal/http-server/handlers/review/save/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		goodsID   string
+		body      string
+		review    storage.Review
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Success",
+			goodsID: "7",
+			body:    `{"author": "ann", "rating": 4, "text": "Fine tea"}`,
+			review:  storage.Review{GoodsID: 7, Author: "ann", Rating: 4, Text: "Fine tea"},
+		},
+		{
+			name:    "Without text",
+			goodsID: "7",
+			body:    `{"author": "bob", "rating": 5}`,
+			review:  storage.Review{GoodsID: 7, Author: "bob", Rating: 5},
+		},
+		{
+			name:      "Rating too high",
+			goodsID:   "7",
+			body:      `{"author": "ann", "rating": 6}`,
+			respError: "field Rating is not valid",
+		},
+		{
+			name:      "Missing rating",
+			goodsID:   "7",
+			body:      `{"author": "ann"}`,
+			respError: "field Rating is a required field",
+		},
+		{
+			name:      "Missing author",
+			goodsID:   "7",
+			body:      `{"rating": 3}`,
+			respError: "field Author is a required field",
+		},
+		{
+			name:      "Invalid goods id",
+			goodsID:   "tea",
+			body:      `{"author": "ann", "rating": 4}`,
+			respError: "invalid request",
+		},
+		{
+			name:      "Goods not found",
+			goodsID:   "7",
+			body:      `{"author": "ann", "rating": 4}`,
+			review:    storage.Review{GoodsID: 7, Author: "ann", Rating: 4},
+			respError: "not found",
+			mockError: storage.ErrGoodsNotFound,
+		},
+		{
+			name:      "SaveReview Error",
+			goodsID:   "7",
+			body:      `{"author": "ann", "rating": 4}`,
+			review:    storage.Review{GoodsID: 7, Author: "ann", Rating: 4},
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewSaverMock := mocks.NewReviewSaver(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				saved := tc.review
+				saved.ID = 1
+				saved.Status = storage.ReviewPending
+
+				reviewSaverMock.On("SaveReview", tc.review).
+					Return(saved, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/goods/{id}/reviews", save.New(slogdiscard.NewDiscardLogger(), reviewSaverMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/goods/"+tc.goodsID+"/reviews", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "1", resp.Review.Id)
+				require.Equal(t, tc.goodsID, resp.Review.GoodsId)
+				require.Equal(t, tc.review.Rating, resp.Review.Rating)
+				require.Equal(t, storage.ReviewPending, resp.Review.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package status
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	"go-api/internal/http-server/handlers/review/read"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type Request struct {
+	Status string `json:"status" validate:"required,oneof=pending approved rejected"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ReviewStatusSetter
+type ReviewStatusSetter interface {
+	SetReviewStatus(id int64, status string) (storage.Review, error)
+}
+
+// New moderates a review. Only approved reviews are shown with the goods
+// and counted in its rating.
+func New(log *slog.Logger, reviewStatusSetter ReviewStatusSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.status.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Info("invalid review id", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		var req Request
+
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		review, err := reviewStatusSetter.SetReviewStatus(id, req.Status)
+		if errors.Is(err, storage.ErrReviewNotFound) {
+			log.Info("review not found", slog.Int64("id", id))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to set review status", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("review moderated", slog.Int64("id", id), slog.String("status", review.Status))
+
+		render.JSON(w, r, read.Response{
+			Response: resp.OK(),
+			Review:   read.ToReview(review),
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package status_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/review/read"
+	"go-api/internal/http-server/handlers/review/status"
+	"go-api/internal/http-server/handlers/review/status/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestStatusHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		status    string
+		respError string
+		mockError error
+	}{
+		{
+			name:   "Approve",
+			body:   `{"status": "approved"}`,
+			status: storage.ReviewApproved,
+		},
+		{
+			name:   "Reject",
+			body:   `{"status": "rejected"}`,
+			status: storage.ReviewRejected,
+		},
+		{
+			name:      "Unknown status",
+			body:      `{"status": "spam"}`,
+			respError: "field Status is not valid",
+		},
+		{
+			name:      "Not found",
+			body:      `{"status": "approved"}`,
+			status:    storage.ReviewApproved,
+			respError: "not found",
+			mockError: storage.ErrReviewNotFound,
+		},
+		{
+			name:      "SetReviewStatus Error",
+			body:      `{"status": "approved"}`,
+			status:    storage.ReviewApproved,
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewStatusSetterMock := mocks.NewReviewStatusSetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewStatusSetterMock.On("SetReviewStatus", int64(5), tc.status).
+					Return(storage.Review{ID: 5, GoodsID: 1, Author: "ann", Rating: 4, Status: tc.status}, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Put("/reviews/{id}/status", status.New(slogdiscard.NewDiscardLogger(), reviewStatusSetterMock))
+
+			req, err := http.NewRequest(http.MethodPut, "/reviews/5/status", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp read.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.status, resp.Review.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package quote
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/money"
+	"go-api/internal/lib/shipping"
+	"go-api/internal/storage"
+)
+
+// Request lists the goods to ship, optionally a variant of them, and the
+// destination zone.
+type Request struct {
+	Zone  string `json:"zone" validate:"required"`
+	Items []Item `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type Item struct {
+	GoodsId  string `json:"goodsId" validate:"required,number"`
+	Sku      string `json:"sku,omitempty" validate:"max=64"`
+	Quantity int64  `json:"quantity" validate:"min=1,max=10000"`
+}
+
+type Option struct {
+	Method   string `json:"method"`
+	Days     int    `json:"days,omitempty"`
+	Price    string `json:"price"`
+	Currency string `json:"currency"`
+}
+
+type Response struct {
+	resp.Response
+	Zone    string   `json:"zone,omitempty"`
+	Weight  int64    `json:"weight,omitempty"`
+	Options []Option `json:"options,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ShippingGoods
+type ShippingGoods interface {
+	GetGoods(id int64) (storage.Goods, error)
+	GetVariant(goodsID int64, sku string) (storage.Variant, error)
+}
+
+// RateTable prices shipments by weight; it is implemented by
+// shipping.Table.
+type RateTable interface {
+	Quote(zone string, weight int64) ([]shipping.Option, error)
+}
+
+// New returns the shipping options for the total weight of the items,
+// taking the weight of a variant where it overrides that of the goods.
+func New(log *slog.Logger, shippingGoods ShippingGoods, rates RateTable) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.shipping.quote.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		weight, err := totalWeight(shippingGoods, req.Items)
+		if errors.Is(err, storage.ErrGoodsNotFound) || errors.Is(err, storage.ErrVariantNotFound) {
+			log.Info("item not found", sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to weigh items", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		options, err := rates.Quote(req.Zone, weight)
+		if errors.Is(err, shipping.ErrUnknownZone) || errors.Is(err, shipping.ErrTooHeavy) {
+			log.Info("cannot ship", slog.String("zone", req.Zone), slog.Int64("weight", weight), sl.Err(err))
+
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to quote shipping", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("shipping quoted", slog.String("zone", req.Zone), slog.Int64("weight", weight))
+
+		res := Response{
+			Response: resp.OK(),
+			Zone:     req.Zone,
+			Weight:   weight,
+			Options:  make([]Option, 0, len(options)),
+		}
+
+		for _, o := range options {
+			res.Options = append(res.Options, Option{
+				Method:   o.Method,
+				Days:     o.Days,
+				Price:    money.Format(o.Price, o.Currency),
+				Currency: o.Currency,
+			})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// totalWeight sums the weights of the items. Errors of missing goods and
+// variants name the goods id and are fit for the client.
+func totalWeight(shippingGoods ShippingGoods, items []Item) (int64, error) {
+	var weight int64
+
+	for _, item := range items {
+		id, err := strconv.ParseInt(item.GoodsId, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		goods, err := shippingGoods.GetGoods(id)
+		if errors.Is(err, storage.ErrGoodsNotFound) {
+			return 0, fmt.Errorf("%w: goods %s", storage.ErrGoodsNotFound, item.GoodsId)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		unit := int64(goods.Weight)
+
+		if item.Sku != "" {
+			variant, err := shippingGoods.GetVariant(id, item.Sku)
+			if errors.Is(err, storage.ErrVariantNotFound) {
+				return 0, fmt.Errorf("%w: goods %s sku %s", storage.ErrVariantNotFound, item.GoodsId, item.Sku)
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			unit = int64(variant.EffectiveWeight(goods))
+		}
+
+		weight += unit * item.Quantity
+	}
+
+	return weight, nil
+}
@@ -0,0 +1,144 @@
+package quote_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/shipping/quote"
+	"go-api/internal/http-server/handlers/shipping/quote/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/lib/shipping"
+	"go-api/internal/storage"
+)
+
+func TestQuoteHandler(t *testing.T) {
+	rates, err := shipping.New([]shipping.Zone{{
+		Name:     "domestic",
+		Currency: "USD",
+		Methods: []shipping.Method{
+			{Name: "standard", Days: 5, Rates: []shipping.Rate{{MaxWeight: 500, Price: 499}, {MaxWeight: 2000, Price: 899}}},
+			{Name: "express", Days: 1, Rates: []shipping.Rate{{MaxWeight: 500, Price: 1299}}},
+		},
+	}})
+	require.NoError(t, err)
+
+	weight := int32(800)
+
+	cases := []struct {
+		name         string
+		body         string
+		invalid      bool
+		variant      bool
+		goodsError   error
+		variantError error
+		respError    string
+		weight       int64
+		prices       []string
+	}{
+		{
+			name:   "Success",
+			body:   `{"zone": "domestic", "items": [{"goodsId": "7", "quantity": 1}]}`,
+			weight: 300,
+			prices: []string{"4.99", "12.99"},
+		},
+		{
+			name:    "Variant weight",
+			body:    `{"zone": "domestic", "items": [{"goodsId": "7", "sku": "TEA-L", "quantity": 2}]}`,
+			variant: true,
+			weight:  1600,
+			prices:  []string{"8.99"},
+		},
+		{
+			name:      "Too heavy",
+			body:      `{"zone": "domestic", "items": [{"goodsId": "7", "sku": "TEA-L", "quantity": 3}]}`,
+			variant:   true,
+			respError: shipping.ErrTooHeavy.Error(),
+		},
+		{
+			name:      "Unknown zone",
+			body:      `{"zone": "mars", "items": [{"goodsId": "7", "quantity": 1}]}`,
+			respError: shipping.ErrUnknownZone.Error(),
+		},
+		{
+			name:      "Missing items",
+			body:      `{"zone": "domestic"}`,
+			invalid:   true,
+			respError: "field Items is a required field",
+		},
+		{
+			name:      "Invalid quantity",
+			body:      `{"zone": "domestic", "items": [{"goodsId": "7", "quantity": 0}]}`,
+			invalid:   true,
+			respError: "field Quantity is not valid",
+		},
+		{
+			name:       "Goods not found",
+			body:       `{"zone": "domestic", "items": [{"goodsId": "7", "quantity": 1}]}`,
+			goodsError: storage.ErrGoodsNotFound,
+			respError:  "goods not found: goods 7",
+		},
+		{
+			name:         "Variant not found",
+			body:         `{"zone": "domestic", "items": [{"goodsId": "7", "sku": "TEA-L", "quantity": 1}]}`,
+			variant:      true,
+			variantError: storage.ErrVariantNotFound,
+			respError:    "variant not found: goods 7 sku TEA-L",
+		},
+		{
+			name:       "GetGoods Error",
+			body:       `{"zone": "domestic", "items": [{"goodsId": "7", "quantity": 1}]}`,
+			goodsError: errors.New("unexpected error"),
+			respError:  "internal error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			shippingGoodsMock := mocks.NewShippingGoods(t)
+
+			if !tc.invalid {
+				shippingGoodsMock.On("GetGoods", int64(7)).
+					Return(storage.Goods{ID: 7, Title: "Tea", Weight: 300}, tc.goodsError).Once()
+			}
+
+			if tc.variant {
+				shippingGoodsMock.On("GetVariant", int64(7), "TEA-L").
+					Return(storage.Variant{GoodsID: 7, SKU: "TEA-L", Weight: &weight}, tc.variantError).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/shipping/quote", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			quote.New(slogdiscard.NewDiscardLogger(), shippingGoodsMock, rates).ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp quote.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.weight, resp.Weight)
+				require.Len(t, resp.Options, len(tc.prices))
+
+				for i, price := range tc.prices {
+					require.Equal(t, price, resp.Options[i].Price)
+					require.Equal(t, "USD", resp.Options[i].Currency)
+				}
+			}
+		})
+	}
+}
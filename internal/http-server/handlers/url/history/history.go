@@ -0,0 +1,185 @@
+package history
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/url/save"
+	"go-api/internal/lib/api/etag"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+// Revision is a previous target of the url: it pointed to URL at Version.
+type Revision struct {
+	Version    int64     `json:"version"`
+	URL        string    `json:"url"`
+	Actor      string    `json:"actor"`
+	ReplacedAt time.Time `json:"replaced_at"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Revisions []Revision `json:"revisions"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLHistory
+type URLHistory interface {
+	ListURLHistory(alias string, limit int, offset int) ([]storage.URLRevision, error)
+	RollbackURL(alias string, version int64, actor string) (storage.URL, error)
+}
+
+// New lists the previous targets of the url, newest first.
+func New(log *slog.Logger, urlHistory URLHistory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.history.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias := chi.URLParam(r, "alias")
+		if alias == "" {
+			log.Info("alias is empty")
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		revisions, err := urlHistory.ListURLHistory(alias, limit, offset)
+		if err != nil {
+			log.Error("failed to list url history", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed url history", slog.String("alias", alias), slog.Int("count", len(revisions)))
+
+		res := ListResponse{
+			Response:  resp.OK(),
+			Revisions: make([]Revision, 0, len(revisions)),
+		}
+
+		for _, rev := range revisions {
+			res.Revisions = append(res.Revisions, Revision{
+				Version:    rev.Version,
+				URL:        rev.URL,
+				Actor:      rev.Actor,
+				ReplacedAt: rev.ReplacedAt,
+			})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// NewRollback points the url back to its target at the version URL
+// parameter. The rollback is a new version of the url.
+func NewRollback(log *slog.Logger, urlHistory URLHistory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.history.NewRollback"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias := chi.URLParam(r, "alias")
+		if alias == "" {
+			log.Info("alias is empty")
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		version, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 64)
+		if err != nil {
+			log.Info("invalid version", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		actor, _, _ := r.BasicAuth()
+
+		url, err := urlHistory.RollbackURL(alias, version, actor)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Info("url not found", slog.String("alias", alias))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrURLRevisionNotFound) {
+			log.Info("url revision not found", slog.String("alias", alias), slog.Int64("version", version))
+
+			render.JSON(w, r, resp.Error("revision not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to roll back url", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to roll back url"))
+
+			return
+		}
+
+		log.Info("url rolled back", slog.String("alias", alias), slog.Int64("to", version))
+
+		w.Header().Set("ETag", etag.Format(url.Version))
+
+		render.JSON(w, r, save.ToResponse(url))
+	}
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
@@ -0,0 +1,125 @@
+package history_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/url/history"
+	"go-api/internal/http-server/handlers/url/history/mocks"
+	"go-api/internal/http-server/handlers/url/save"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestListHandler(t *testing.T) {
+	urlHistoryMock := mocks.NewURLHistory(t)
+
+	replacedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	urlHistoryMock.On("ListURLHistory", "docs", 10, 0).
+		Return([]storage.URLRevision{
+			{ID: 2, Version: 2, URL: "https://example.com/v2", Actor: "admin", ReplacedAt: replacedAt},
+			{ID: 1, Version: 1, URL: "https://example.com/v1", Actor: "admin", ReplacedAt: replacedAt},
+		}, nil).Once()
+
+	r := chi.NewRouter()
+	r.Get("/url/{alias}/history", history.New(slogdiscard.NewDiscardLogger(), urlHistoryMock))
+
+	req, err := http.NewRequest(http.MethodGet, "/url/docs/history?limit=10", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	var resp history.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+	require.Len(t, resp.Revisions, 2)
+	require.Equal(t, int64(2), resp.Revisions[0].Version)
+	require.Equal(t, "https://example.com/v2", resp.Revisions[0].URL)
+	require.True(t, replacedAt.Equal(resp.Revisions[1].ReplacedAt))
+}
+
+func TestRollbackHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		version   string
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Success",
+			version: "1",
+		},
+		{
+			name:      "Invalid version",
+			version:   "abc",
+			respError: "invalid request",
+		},
+		{
+			name:      "URL not found",
+			version:   "1",
+			respError: "not found",
+			mockError: storage.ErrURLNotFound,
+		},
+		{
+			name:      "Revision not found",
+			version:   "1",
+			respError: "revision not found",
+			mockError: storage.ErrURLRevisionNotFound,
+		},
+		{
+			name:      "RollbackURL Error",
+			version:   "1",
+			respError: "failed to roll back url",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlHistoryMock := mocks.NewURLHistory(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				urlHistoryMock.On("RollbackURL", "docs", int64(1), "admin").
+					Return(storage.URL{ID: 1, Alias: "docs", URL: "https://example.com/v1", Version: 4}, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Post("/url/{alias}/history/{version}/rollback", history.NewRollback(slogdiscard.NewDiscardLogger(), urlHistoryMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/url/docs/history/"+tc.version+"/rollback", nil)
+			require.NoError(t, err)
+
+			req.SetBasicAuth("admin", "secret")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, "https://example.com/v1", resp.URL)
+				require.Equal(t, `"4"`, rr.Header().Get("ETag"))
+			}
+		})
+	}
+}
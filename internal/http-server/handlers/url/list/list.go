@@ -0,0 +1,173 @@
+package list
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+var ErrInvalidPeriod = errors.New("created_from must be before created_to")
+
+type URL struct {
+	Alias     string    `json:"alias"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	Clicks    int64     `json:"clicks"`
+}
+
+// ListRequest is read from the query. Q is searched for in the alias and
+// the target of the urls, anywhere in them or as a prefix with
+// match=prefix. The created-at bounds are RFC 3339 times.
+type ListRequest struct {
+	Limit       int    `validate:"min=0,max=100"`
+	Offset      int    `validate:"min=0"`
+	Q           string `validate:"max=200"`
+	Match       string `validate:"omitempty,oneof=contains prefix"`
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Sort        string `validate:"omitempty,oneof=created alias url clicks"`
+	Order       string `validate:"omitempty,oneof=asc desc"`
+}
+
+type ListResponse struct {
+	resp.Response
+	URLs []URL `json:"urls"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLLister
+type URLLister interface {
+	ListURLs(opts storage.URLListOptions) ([]storage.URLSummary, error)
+}
+
+// New lists the urls, oldest first unless sorted otherwise, with the
+// number of redirects through each.
+func New(log *slog.Logger, urlLister URLLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.list.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		req, err := parseListRequest(r)
+		if err != nil {
+			log.Info("failed to parse query", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Info("invalid request", sl.Err(err))
+
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && !req.CreatedFrom.Before(req.CreatedTo) {
+			log.Info("invalid request", sl.Err(ErrInvalidPeriod))
+
+			render.JSON(w, r, resp.Error(ErrInvalidPeriod.Error()))
+
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultLimit
+		}
+
+		urls, err := urlLister.ListURLs(storage.URLListOptions{
+			Query:       req.Q,
+			Prefix:      req.Match == "prefix",
+			CreatedFrom: req.CreatedFrom,
+			CreatedTo:   req.CreatedTo,
+			SortBy:      req.Sort,
+			Desc:        req.Order == "desc",
+			Limit:       limit,
+			Offset:      req.Offset,
+		})
+		if err != nil {
+			log.Error("failed to list urls", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed urls", slog.Int("count", len(urls)))
+
+		res := ListResponse{
+			Response: resp.OK(),
+			URLs:     make([]URL, 0, len(urls)),
+		}
+
+		for _, u := range urls {
+			res.URLs = append(res.URLs, URL{
+				Alias:     u.Alias,
+				URL:       u.URL.URL,
+				CreatedAt: u.CreatedAt,
+				Clicks:    u.Clicks,
+			})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parseListRequest(r *http.Request) (ListRequest, error) {
+	var (
+		req ListRequest
+		err error
+	)
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("created_from"); v != "" {
+		if req.CreatedFrom, err = time.Parse(time.RFC3339, v); err != nil {
+			return req, err
+		}
+	}
+
+	if v := q.Get("created_to"); v != "" {
+		if req.CreatedTo, err = time.Parse(time.RFC3339, v); err != nil {
+			return req, err
+		}
+	}
+
+	req.Q = q.Get("q")
+	req.Match = q.Get("match")
+	req.Sort = q.Get("sort")
+	req.Order = q.Get("order")
+
+	return req, nil
+}
@@ -0,0 +1,120 @@
+package list_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/url/list"
+	"go-api/internal/http-server/handlers/url/list/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestListHandler(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		query     string
+		opts      storage.URLListOptions
+		urls      []storage.URLSummary
+		respError string
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			opts:  storage.URLListOptions{Limit: 20},
+			urls: []storage.URLSummary{
+				{URL: storage.URL{Alias: "docs", URL: "https://example.com/docs", CreatedAt: from}, Clicks: 12},
+				{URL: storage.URL{Alias: "blog", URL: "https://example.com/blog", CreatedAt: to}},
+			},
+		},
+		{
+			name:  "Search",
+			query: "?q=example.com&sort=clicks&order=desc",
+			opts:  storage.URLListOptions{Query: "example.com", SortBy: "clicks", Desc: true, Limit: 20},
+			urls:  []storage.URLSummary{{URL: storage.URL{Alias: "docs"}}},
+		},
+		{
+			name:  "Prefix page",
+			query: "?q=do&match=prefix&limit=5&offset=10&sort=alias",
+			opts:  storage.URLListOptions{Query: "do", Prefix: true, SortBy: "alias", Limit: 5, Offset: 10},
+			urls:  []storage.URLSummary{},
+		},
+		{
+			name:  "Created between",
+			query: "?created_from=2024-01-01T00:00:00Z&created_to=2024-02-01T00:00:00Z",
+			opts:  storage.URLListOptions{CreatedFrom: from, CreatedTo: to, Limit: 20},
+			urls:  []storage.URLSummary{},
+		},
+		{
+			name:      "Created backwards",
+			query:     "?created_from=2024-02-01T00:00:00Z&created_to=2024-01-01T00:00:00Z",
+			respError: list.ErrInvalidPeriod.Error(),
+		},
+		{
+			name:      "Invalid created time",
+			query:     "?created_from=yesterday",
+			respError: "invalid request",
+		},
+		{
+			name:      "Invalid match",
+			query:     "?q=do&match=suffix",
+			respError: "field Match is not valid",
+		},
+		{
+			name:      "Invalid sort",
+			query:     "?sort=id",
+			respError: "field Sort is not valid",
+		},
+		{
+			name:      "Limit too big",
+			query:     "?limit=1000",
+			respError: "field Limit is not valid",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlListerMock := mocks.NewURLLister(t)
+
+			if tc.respError == "" {
+				urlListerMock.On("ListURLs", tc.opts).
+					Return(tc.urls, nil).Once()
+			}
+
+			handler := list.New(slogdiscard.NewDiscardLogger(), urlListerMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/url"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp list.ListResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			require.Len(t, resp.URLs, len(tc.urls))
+
+			for i, u := range tc.urls {
+				require.Equal(t, u.Alias, resp.URLs[i].Alias)
+				require.Equal(t, u.URL.URL, resp.URLs[i].URL)
+				require.Equal(t, u.Clicks, resp.URLs[i].Clicks)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package save
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const (
+	maxBatchSize = 1000
+	// maxBatchPasswords caps the items with a password, since each takes
+	// a bcrypt hash: a full batch of them would run far past the server
+	// write timeout.
+	maxBatchPasswords = 10
+)
+
+// BatchResult is the outcome of an item of the batch, in the order of
+// the request: the alias of the saved url or the reason it was not saved.
+type BatchResult struct {
+	Alias string `json:"alias,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	resp.Response
+	Saved   int           `json:"saved"`
+	Failed  int           `json:"failed"`
+	Results []BatchResult `json:"results"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLBatchSaver
+type URLBatchSaver interface {
+	SaveURLBatch(urls []storage.URL, atomic bool, generate func(id int64) (string, error)) ([]error, error)
+}
+
+// NewBatch saves an array of requests in a single transaction. Each item
+// is validated like a single save and fails on its own, unless the atomic
+// query parameter is true: then no url is saved if any item fails. Items
+// without an alias get one from aliases. Only maxBatchPasswords items may
+// have a password.
+func NewBatch(log *slog.Logger, urlSaver URLBatchSaver, aliases AliasGenerator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.save.NewBatch"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		atomic := r.URL.Query().Get("atomic") == "true"
+
+		var reqs []Request
+
+		err := render.DecodeJSON(r.Body, &reqs)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		if len(reqs) == 0 || len(reqs) > maxBatchSize {
+			log.Info("invalid batch size", slog.Int("size", len(reqs)))
+
+			render.JSON(w, r, resp.Error("batch must have 1 to 1000 urls"))
+
+			return
+		}
+
+		passwords := 0
+		for _, req := range reqs {
+			if req.Password != "" {
+				passwords++
+			}
+		}
+
+		if passwords > maxBatchPasswords {
+			log.Info("too many passwords in batch", slog.Int("passwords", passwords))
+
+			render.JSON(w, r, resp.Error(fmt.Sprintf("batch must have at most %d urls with a password", maxBatchPasswords)))
+
+			return
+		}
+
+		res := BatchResponse{
+			Response: resp.OK(),
+			Results:  make([]BatchResult, len(reqs)),
+		}
+
+		var (
+			urls    []storage.URL
+			indexes []int
+		)
+
+		for i, req := range reqs {
+			url, err := Validate(req)
+			if err != nil {
+				res.Results[i].Error = ValidationResponse(err).Error
+				res.Failed++
+
+				continue
+			}
+
+			urls = append(urls, url)
+			indexes = append(indexes, i)
+		}
+
+		if atomic && res.Failed > 0 {
+			log.Info("batch rejected", slog.Int("failed", res.Failed))
+
+			rejectBatch(w, r, res, indexes)
+
+			return
+		}
+
+		var errs []error
+		if len(urls) > 0 {
+			errs, err = urlSaver.SaveURLBatch(urls, atomic, aliases.Generate)
+			if err != nil {
+				log.Error("failed to add urls", sl.Err(err))
+
+				render.JSON(w, r, resp.Error("failed to add urls"))
+
+				return
+			}
+		}
+
+		for j, i := range indexes {
+			if errors.Is(errs[j], storage.ErrURLExists) {
+				res.Results[i].Error = "url already exists"
+				res.Failed++
+			}
+		}
+
+		if atomic && res.Failed > 0 {
+			log.Info("batch rejected", slog.Int("failed", res.Failed))
+
+			rejectBatch(w, r, res, indexes)
+
+			return
+		}
+
+		for j, i := range indexes {
+			if errs[j] == nil {
+				res.Results[i].Alias = urls[j].Alias
+				res.Saved++
+			}
+		}
+
+		log.Info("urls added", slog.Int("saved", res.Saved), slog.Int("failed", res.Failed))
+
+		render.JSON(w, r, res)
+	}
+}
+
+// rejectBatch responds to an atomic batch that was not saved, marking the
+// items that did not fail themselves as not saved.
+func rejectBatch(w http.ResponseWriter, r *http.Request, res BatchResponse, indexes []int) {
+	for _, i := range indexes {
+		if res.Results[i].Error == "" {
+			res.Results[i].Error = "not saved"
+		}
+	}
+
+	res.Response = resp.Error("batch rejected")
+	res.Saved, res.Failed = 0, len(res.Results)
+
+	render.JSON(w, r, res)
+}
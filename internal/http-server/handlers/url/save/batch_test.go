@@ -0,0 +1,159 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/url/save"
+	"go-api/internal/http-server/handlers/url/save/mocks"
+	"go-api/internal/lib/alias"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestBatchHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		body      string
+		saved     int
+		mockErrs  []error
+		mockError error
+		results   []save.BatchResult
+		respError string
+	}{
+		{
+			name:     "Success",
+			body:     `[{"url":"https://a.com","alias":"a"},{"url":"https://b.com","alias":"b"}]`,
+			saved:    2,
+			mockErrs: []error{nil, nil},
+			results:  []save.BatchResult{{Alias: "a"}, {Alias: "b"}},
+		},
+		{
+			name:     "Partial",
+			body:     `[{"url":"https://a.com","alias":"a"},{"url":"not a url","alias":"b"},{"url":"https://c.com","alias":"c"}]`,
+			saved:    2,
+			mockErrs: []error{storage.ErrURLExists, nil},
+			results: []save.BatchResult{
+				{Error: "url already exists"},
+				{Error: "field URL is not a valid URL"},
+				{Alias: "c"},
+			},
+		},
+		{
+			name:  "Atomic invalid item",
+			query: "?atomic=true",
+			body:  `[{"url":"https://a.com","alias":"a"},{"url":"not a url","alias":"b"}]`,
+			results: []save.BatchResult{
+				{Error: "not saved"},
+				{Error: "field URL is not a valid URL"},
+			},
+			respError: "batch rejected",
+		},
+		{
+			name:     "Atomic alias taken",
+			query:    "?atomic=true",
+			body:     `[{"url":"https://a.com","alias":"a"},{"url":"https://b.com","alias":"b"}]`,
+			saved:    2,
+			mockErrs: []error{nil, storage.ErrURLExists},
+			results: []save.BatchResult{
+				{Error: "not saved"},
+				{Error: "url already exists"},
+			},
+			respError: "batch rejected",
+		},
+		{
+			name:      "Empty",
+			body:      `[]`,
+			respError: "batch must have 1 to 1000 urls",
+		},
+		{
+			name:      "Too many passwords",
+			body:      "[" + strings.Repeat(`{"url":"https://a.com","password":"s3cret"},`, 10) + `{"url":"https://b.com","password":"s3cret"}]`,
+			respError: "batch must have at most 10 urls with a password",
+		},
+		{
+			name:      "Not an array",
+			body:      `{"url":"https://a.com"}`,
+			respError: "failed to decode request",
+		},
+		{
+			name:      "SaveURLBatch Error",
+			body:      `[{"url":"https://a.com","alias":"a"}]`,
+			saved:     1,
+			mockError: errors.New("unexpected error"),
+			respError: "failed to add urls",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlSaverMock := mocks.NewURLBatchSaver(t)
+
+			if tc.saved > 0 {
+				urlSaverMock.On("SaveURLBatch", mock.MatchedBy(func(urls []storage.URL) bool {
+					return len(urls) == tc.saved
+				}), tc.query != "", mock.Anything).
+					Return(tc.mockErrs, tc.mockError).Once()
+			}
+
+			handler := save.NewBatch(slogdiscard.NewDiscardLogger(), urlSaverMock, alias.Base62{})
+
+			req, err := http.NewRequest(http.MethodPost, "/url/batch"+tc.query, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp save.BatchResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.results, resp.Results)
+		})
+	}
+}
+
+func TestBatchGeneratesAliases(t *testing.T) {
+	urlSaverMock := mocks.NewURLBatchSaver(t)
+
+	urlSaverMock.On("SaveURLBatch", mock.AnythingOfType("[]storage.URL"), false, mock.Anything).
+		Return(func(urls []storage.URL, atomic bool, generate func(int64) (string, error)) ([]error, error) {
+			for i := range urls {
+				if urls[i].Alias == "" {
+					urls[i].Alias, _ = generate(int64(i + 10))
+				}
+			}
+
+			return make([]error, len(urls)), nil
+		}).Once()
+
+	req, err := http.NewRequest(http.MethodPost, "/url/batch",
+		bytes.NewBufferString(`[{"url":"https://a.com"},{"url":"https://b.com","alias":"b"},{"url":"https://c.com"}]`))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	save.NewBatch(slogdiscard.NewDiscardLogger(), urlSaverMock, alias.Base62{}).ServeHTTP(rr, req)
+
+	var resp save.BatchResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Empty(t, resp.Error)
+	require.Equal(t, 3, resp.Saved)
+	require.Equal(t, []save.BatchResult{{Alias: "a"}, {Alias: "b"}, {Alias: "c"}}, resp.Results)
+}
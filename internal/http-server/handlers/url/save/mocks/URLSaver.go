@@ -2,30 +2,57 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+	storage "go-api/internal/storage"
+)
 
 // URLSaver is an autogenerated mock type for the URLSaver type
 type URLSaver struct {
 	mock.Mock
 }
 
-// SaveURL provides a mock function with given fields: urlToSave, alias
-func (_m *URLSaver) SaveURL(urlToSave string, alias string) (int64, error) {
-	ret := _m.Called(urlToSave, alias)
+// SaveGeneratedURL provides a mock function with given fields: url, generate
+func (_m *URLSaver) SaveGeneratedURL(url storage.URL, generate func(int64) (string, error)) (storage.URL, error) {
+	ret := _m.Called(url, generate)
+
+	var r0 storage.URL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(storage.URL, func(int64) (string, error)) (storage.URL, error)); ok {
+		return rf(url, generate)
+	}
+	if rf, ok := ret.Get(0).(func(storage.URL, func(int64) (string, error)) storage.URL); ok {
+		r0 = rf(url, generate)
+	} else {
+		r0 = ret.Get(0).(storage.URL)
+	}
+
+	if rf, ok := ret.Get(1).(func(storage.URL, func(int64) (string, error)) error); ok {
+		r1 = rf(url, generate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveURL provides a mock function with given fields: url
+func (_m *URLSaver) SaveURL(url storage.URL) (int64, error) {
+	ret := _m.Called(url)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return rf(urlToSave, alias)
+	if rf, ok := ret.Get(0).(func(storage.URL) (int64, error)); ok {
+		return rf(url)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(urlToSave, alias)
+	if rf, ok := ret.Get(0).(func(storage.URL) int64); ok {
+		r0 = rf(url)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(urlToSave, alias)
+	if rf, ok := ret.Get(1).(func(storage.URL) error); ok {
+		r1 = rf(url)
 	} else {
 		r1 = ret.Error(1)
 	}
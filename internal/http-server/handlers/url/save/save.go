@@ -2,39 +2,86 @@ package save
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"log/slog"
 
 	resp "go-api/internal/lib/api/response"
 	"go-api/internal/lib/logger/sl"
-	"go-api/internal/lib/random"
 	"go-api/internal/storage"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// maxPasswordBytes is the most bcrypt hashes of a password.
+const maxPasswordBytes = 72
+
+// Request optionally limits the life of the link: it expires at
+// ExpiresAt, or once followed MaxClicks times. A link with a Password
+// asks for it before redirecting; it may be up to 72 bytes long, as bcrypt
+// ignores the bytes beyond.
 type Request struct {
-	URL   string `json:"url" validate:"required,url"`
-	Alias string `json:"alias,omitempty"`
+	URL       string     `json:"url" validate:"required,url"`
+	Alias     string     `json:"alias,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxClicks int64      `json:"max_clicks,omitempty" validate:"min=0"`
+	Password  string     `json:"password,omitempty" validate:"omitempty,min=4,max=72"`
+}
+
+// LogValue logs the request with its password redacted.
+func (req Request) LogValue() slog.Value {
+	password := ""
+	if req.Password != "" {
+		password = "REDACTED"
+	}
+
+	return slog.GroupValue(
+		slog.String("url", req.URL),
+		slog.String("alias", req.Alias),
+		slog.Any("expires_at", req.ExpiresAt),
+		slog.Int64("max_clicks", req.MaxClicks),
+		slog.String("password", password),
+	)
 }
 
+// Response of the url writes; only the alias is returned on save.
 type Response struct {
 	resp.Response
-	Alias string `json:"alias,omitempty"`
+	Alias     string     `json:"alias,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxClicks int64      `json:"max_clicks,omitempty"`
+	Protected bool       `json:"protected,omitempty"`
+	Version   int64      `json:"version,omitempty"`
 }
 
-// TODO: move to config if needed
-const aliasLength = 6
+var (
+	ErrExpiresInPast   = errors.New("expires_at must be in the future")
+	ErrInvalidPassword = errors.New("invalid password")
+	ErrPasswordTooLong = errors.New("password must be at most 72 bytes")
+)
+
+// AliasGenerator makes the aliases of urls saved without one from the id
+// the url is saved with; the strategies are in lib/alias. It is called
+// again with a new id while the alias is taken.
+type AliasGenerator interface {
+	Generate(id int64) (string, error)
+}
 
 //go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLSaver
 type URLSaver interface {
-	SaveURL(urlToSave string, alias string) (int64, error)
+	SaveURL(url storage.URL) (int64, error)
+	SaveGeneratedURL(url storage.URL, generate func(id int64) (string, error)) (storage.URL, error)
 }
 
-func New(log *slog.Logger, urlSaver URLSaver) http.HandlerFunc {
+// New saves the url of the request. An url without an alias gets one
+// from aliases.
+func New(log *slog.Logger, urlSaver URLSaver, aliases AliasGenerator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.url.save.New"
 
@@ -56,22 +103,20 @@ func New(log *slog.Logger, urlSaver URLSaver) http.HandlerFunc {
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
-			validateErr := err.(validator.ValidationErrors)
-
+		url, err := Validate(req)
+		if err != nil {
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.JSON(w, r, ValidationResponse(err))
 
 			return
 		}
 
-		alias := req.Alias
-		if alias == "" {
-			alias = random.NewRandomString(aliasLength)
+		if url.Alias == "" {
+			url, err = urlSaver.SaveGeneratedURL(url, aliases.Generate)
+		} else {
+			url.ID, err = urlSaver.SaveURL(url)
 		}
-
-		id, err := urlSaver.SaveURL(req.URL, alias)
 		if errors.Is(err, storage.ErrURLExists) {
 			log.Info("url already exists", slog.String("url", req.URL))
 
@@ -87,12 +132,91 @@ func New(log *slog.Logger, urlSaver URLSaver) http.HandlerFunc {
 			return
 		}
 
-		log.Info("url added", slog.Int64("id", id))
+		log.Info("url added", slog.Int64("id", url.ID))
+
+		responseOK(w, r, url.Alias)
+	}
+}
+
+// Validate checks the request and converts it to the url to save, hashing
+// its password. The alias is left empty if the request has none.
+func Validate(req Request) (storage.URL, error) {
+	if err := validator.New().Struct(req); err != nil {
+		return storage.URL{}, err
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return storage.URL{}, ErrExpiresInPast
+	}
+
+	// the validator counts characters, not bytes
+	if len(req.Password) > maxPasswordBytes {
+		return storage.URL{}, ErrPasswordTooLong
+	}
+
+	var passwordHash string
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return storage.URL{}, fmt.Errorf("%w: %w", ErrInvalidPassword, err)
+		}
+
+		passwordHash = string(hash)
+	}
+
+	return storage.URL{
+		Alias:        req.Alias,
+		URL:          req.URL,
+		ExpiresAt:    expiresAt,
+		MaxClicks:    req.MaxClicks,
+		PasswordHash: passwordHash,
+	}, nil
+}
+
+// ValidationResponse converts an error returned by Validate to a response.
+func ValidationResponse(err error) resp.Response {
+	var validateErr validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &validateErr):
+		return resp.ValidationError(validateErr)
+	case errors.Is(err, ErrExpiresInPast):
+		return resp.Error(ErrExpiresInPast.Error())
+	case errors.Is(err, ErrInvalidPassword):
+		return resp.Error(ErrInvalidPassword.Error())
+	case errors.Is(err, ErrPasswordTooLong):
+		return resp.Error(ErrPasswordTooLong.Error())
+	default:
+		return resp.Error("invalid request")
+	}
+}
 
-		responseOK(w, r, alias)
+// ToResponse converts a saved url to the response of every url write.
+func ToResponse(url storage.URL) Response {
+	return Response{
+		Response:  resp.OK(),
+		Alias:     url.Alias,
+		URL:       url.URL,
+		ExpiresAt: toTimePtr(url.ExpiresAt),
+		MaxClicks: url.MaxClicks,
+		Protected: url.PasswordHash != "",
+		Version:   url.Version,
 	}
 }
 
+func toTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
 func responseOK(w http.ResponseWriter, r *http.Request, alias string) {
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
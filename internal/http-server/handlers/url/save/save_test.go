@@ -5,16 +5,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
 	"go-api/internal/http-server/handlers/url/save"
 	"go-api/internal/http-server/handlers/url/save/mocks"
+	"go-api/internal/lib/alias"
 	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -22,6 +27,9 @@ func TestSaveHandler(t *testing.T) {
 		name      string
 		alias     string
 		url       string
+		extra     string
+		maxClicks int64
+		password  string
 		respError string
 		mockError error
 	}{
@@ -47,6 +55,55 @@ func TestSaveHandler(t *testing.T) {
 			alias:     "some_alias",
 			respError: "field URL is not a valid URL",
 		},
+		{
+			name:      "Expiring",
+			alias:     "test_alias",
+			url:       "https://google.com",
+			extra:     `, "expires_at": "2999-01-01T00:00:00Z", "max_clicks": 5`,
+			maxClicks: 5,
+		},
+		{
+			name:      "Expired",
+			alias:     "test_alias",
+			url:       "https://google.com",
+			extra:     `, "expires_at": "2000-01-01T00:00:00Z"`,
+			respError: save.ErrExpiresInPast.Error(),
+		},
+		{
+			name:      "Negative max clicks",
+			alias:     "test_alias",
+			url:       "https://google.com",
+			extra:     `, "max_clicks": -1`,
+			respError: "field MaxClicks is not valid",
+		},
+		{
+			name:     "Password",
+			alias:    "test_alias",
+			url:      "https://google.com",
+			extra:    `, "password": "s3cret"`,
+			password: "s3cret",
+		},
+		{
+			name:      "Short password",
+			alias:     "test_alias",
+			url:       "https://google.com",
+			extra:     `, "password": "abc"`,
+			respError: "field Password is not valid",
+		},
+		{
+			name:      "Multi-byte password over 72 bytes",
+			alias:     "test_alias",
+			url:       "https://google.com",
+			extra:     `, "password": "` + strings.Repeat("é", 37) + `"`,
+			respError: save.ErrPasswordTooLong.Error(),
+		},
+		{
+			name:      "Alias taken",
+			alias:     "test_alias",
+			url:       "https://google.com",
+			respError: "url already exists",
+			mockError: storage.ErrURLExists,
+		},
 		{
 			name:      "SaveURL Error",
 			alias:     "test_alias",
@@ -64,14 +121,30 @@ func TestSaveHandler(t *testing.T) {
 
 			urlSaverMock := mocks.NewURLSaver(t)
 
-			if tc.respError == "" || tc.mockError != nil {
-				urlSaverMock.On("SaveURL", tc.url, mock.AnythingOfType("string")).
+			matchesCase := mock.MatchedBy(func(u storage.URL) bool {
+				if tc.password == "" {
+					return u.URL == tc.url && u.MaxClicks == tc.maxClicks && u.PasswordHash == ""
+				}
+
+				return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(tc.password)) == nil
+			})
+
+			if (tc.respError == "" || tc.mockError != nil) && tc.alias == "" {
+				urlSaverMock.On("SaveGeneratedURL", matchesCase, mock.Anything).
+					Return(func(u storage.URL, generate func(int64) (string, error)) (storage.URL, error) {
+						u.ID = 1
+						u.Alias, _ = generate(u.ID)
+
+						return u, tc.mockError
+					}).Once()
+			} else if tc.respError == "" || tc.mockError != nil {
+				urlSaverMock.On("SaveURL", matchesCase).
 					Return(int64(1), tc.mockError).Once()
 			}
 
-			handler := save.New(slogdiscard.NewDiscardLogger(), urlSaverMock)
+			handler := save.New(slogdiscard.NewDiscardLogger(), urlSaverMock, alias.Base62{})
 
-			input := fmt.Sprintf(`{"url": "%s", "alias": "%s"}`, tc.url, tc.alias)
+			input := fmt.Sprintf(`{"url": "%s", "alias": "%s"%s}`, tc.url, tc.alias, tc.extra)
 
 			req, err := http.NewRequest(http.MethodPost, "/save", bytes.NewBuffer([]byte(input)))
 			require.NoError(t, err)
@@ -93,3 +166,88 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveGeneratedAlias(t *testing.T) {
+	cases := []struct {
+		name      string
+		aliases   save.AliasGenerator
+		alias     string
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Base62",
+			aliases: alias.Base62{},
+			alias:   "G",
+		},
+		{
+			name:    "Hashids",
+			aliases: alias.NewHashids("this is my salt", 0),
+			alias:   "eP",
+		},
+		{
+			name:      "All taken",
+			aliases:   alias.Base62{},
+			respError: "url already exists",
+			mockError: fmt.Errorf("storage.sqlite.SaveGeneratedURL: %w", storage.ErrURLExists),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlSaverMock := mocks.NewURLSaver(t)
+
+			urlSaverMock.On("SaveGeneratedURL", mock.AnythingOfType("storage.URL"), mock.Anything).
+				Return(func(u storage.URL, generate func(int64) (string, error)) (storage.URL, error) {
+					if tc.mockError != nil {
+						return storage.URL{}, tc.mockError
+					}
+
+					u.ID = 42
+					u.Alias, _ = generate(u.ID)
+
+					return u, nil
+				}).Once()
+
+			handler := save.New(slogdiscard.NewDiscardLogger(), urlSaverMock, tc.aliases)
+
+			req, err := http.NewRequest(http.MethodPost, "/save", bytes.NewBufferString(`{"url": "https://google.com"}`))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.alias, resp.Alias)
+		})
+	}
+}
+
+func TestSaveHandlerRedactsPassword(t *testing.T) {
+	urlSaverMock := mocks.NewURLSaver(t)
+	urlSaverMock.On("SaveURL", mock.AnythingOfType("storage.URL")).
+		Return(int64(1), nil).Once()
+
+	var logs bytes.Buffer
+
+	handler := save.New(slog.New(slog.NewJSONHandler(&logs, nil)), urlSaverMock, alias.Base62{})
+
+	input := `{"url": "https://google.com", "alias": "test_alias", "password": "secret-pass"}`
+
+	req, err := http.NewRequest(http.MethodPost, "/save", bytes.NewBufferString(input))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, logs.String(), `"password":"REDACTED"`)
+	require.NotContains(t, logs.String(), "secret-pass")
+}
@@ -0,0 +1,152 @@
+package stats
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+// buckets are the bucket sizes by name, with the default and the longest
+// period that may be split into them.
+var buckets = map[string]struct {
+	size          time.Duration
+	defaultPeriod time.Duration
+	maxPeriod     time.Duration
+}{
+	"hour": {size: time.Hour, defaultPeriod: 48 * time.Hour, maxPeriod: 31 * 24 * time.Hour},
+	"day":  {size: 24 * time.Hour, defaultPeriod: 30 * 24 * time.Hour, maxPeriod: 366 * 24 * time.Hour},
+}
+
+const defaultBucket = "day"
+
+type Bucket struct {
+	Start  time.Time `json:"start"`
+	Clicks int64     `json:"clicks"`
+	Unique int64     `json:"unique"`
+}
+
+type Response struct {
+	resp.Response
+	Alias   string    `json:"alias,omitempty"`
+	Total   int64     `json:"total"`
+	Unique  int64     `json:"unique"`
+	Bucket  string    `json:"bucket,omitempty"`
+	From    time.Time `json:"from,omitempty"`
+	To      time.Time `json:"to,omitempty"`
+	Buckets []Bucket  `json:"buckets,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=ClickStatsGetter
+type ClickStatsGetter interface {
+	GetClickStats(alias string, from time.Time, to time.Time, bucket time.Duration) (storage.ClickStats, error)
+}
+
+// New returns the total and unique clicks of a url, and those in hour or
+// day buckets from the from until the to query parameters, RFC 3339
+// times. The period defaults to the last 48 hours for hour buckets and
+// the last 30 days for day buckets. Buckets without clicks are left out.
+func New(log *slog.Logger, clickStatsGetter ClickStatsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.stats.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias := chi.URLParam(r, "alias")
+
+		name, from, to, err := parsePeriod(r, time.Now())
+		if err != nil {
+			log.Info("invalid period", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		stats, err := clickStatsGetter.GetClickStats(alias, from, to, buckets[name].size)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Info("url not found", slog.String("alias", alias))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get click stats", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("got click stats", slog.String("alias", alias), slog.Int64("total", stats.Total))
+
+		res := Response{
+			Response: resp.OK(),
+			Alias:    alias,
+			Total:    stats.Total,
+			Unique:   stats.Unique,
+			Bucket:   name,
+			From:     from,
+			To:       to,
+			Buckets:  make([]Bucket, 0, len(stats.Buckets)),
+		}
+
+		for _, b := range stats.Buckets {
+			res.Buckets = append(res.Buckets, Bucket{Start: b.Start, Clicks: b.Clicks, Unique: b.Unique})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func parsePeriod(r *http.Request, now time.Time) (string, time.Time, time.Time, error) {
+	q := r.URL.Query()
+
+	name := q.Get("bucket")
+	if name == "" {
+		name = defaultBucket
+	}
+
+	bucket, ok := buckets[name]
+	if !ok {
+		return "", time.Time{}, time.Time{}, errors.New("unknown bucket")
+	}
+
+	to := now.UTC()
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, err
+		}
+
+		to = t.UTC()
+	}
+
+	from := to.Add(-bucket.defaultPeriod)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, err
+		}
+
+		from = t.UTC()
+	}
+
+	if !from.Before(to) || to.Sub(from) > bucket.maxPeriod {
+		return "", time.Time{}, time.Time{}, errors.New("invalid period")
+	}
+
+	return name, from, to, nil
+}
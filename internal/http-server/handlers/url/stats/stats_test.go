@@ -0,0 +1,147 @@
+package stats_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/url/stats"
+	"go-api/internal/http-server/handlers/url/stats/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestStatsHandler(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+
+	clickStats := storage.ClickStats{
+		Total:  10,
+		Unique: 4,
+		Buckets: []storage.ClickBucket{
+			{Start: from, Clicks: 3, Unique: 2},
+			{Start: from.Add(24 * time.Hour), Clicks: 1, Unique: 1},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		query     string
+		from      time.Time
+		to        time.Time
+		bucket    time.Duration
+		respError string
+		mockError error
+	}{
+		{
+			name:   "Days",
+			query:  "?from=2026-10-01T00:00:00Z&to=2026-10-03T00:00:00Z",
+			from:   from,
+			to:     to,
+			bucket: 24 * time.Hour,
+		},
+		{
+			name:   "Hours",
+			query:  "?bucket=hour&from=2026-10-01T03:00:00%2B03:00&to=2026-10-03T00:00:00Z",
+			from:   from,
+			to:     to,
+			bucket: time.Hour,
+		},
+		{
+			name:   "Default period",
+			query:  "?bucket=hour",
+			bucket: time.Hour,
+		},
+		{
+			name:      "Unknown bucket",
+			query:     "?bucket=week",
+			respError: "invalid request",
+		},
+		{
+			name:      "Invalid time",
+			query:     "?from=yesterday",
+			respError: "invalid request",
+		},
+		{
+			name:      "Reversed period",
+			query:     "?from=2026-10-03T00:00:00Z&to=2026-10-01T00:00:00Z",
+			respError: "invalid request",
+		},
+		{
+			name:      "Too many hours",
+			query:     "?bucket=hour&from=2026-01-01T00:00:00Z&to=2026-10-01T00:00:00Z",
+			respError: "invalid request",
+		},
+		{
+			name:      "Not found",
+			query:     "?from=2026-10-01T00:00:00Z&to=2026-10-03T00:00:00Z",
+			from:      from,
+			to:        to,
+			bucket:    24 * time.Hour,
+			respError: "not found",
+			mockError: storage.ErrURLNotFound,
+		},
+		{
+			name:      "GetClickStats Error",
+			query:     "?from=2026-10-01T00:00:00Z&to=2026-10-03T00:00:00Z",
+			from:      from,
+			to:        to,
+			bucket:    24 * time.Hour,
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			clickStatsGetterMock := mocks.NewClickStatsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				// the default period ends now
+				fromArg, toArg := any(tc.from), any(tc.to)
+				if tc.from.IsZero() {
+					fromArg, toArg = mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")
+				}
+
+				clickStatsGetterMock.On("GetClickStats", "abc", fromArg, toArg, tc.bucket).
+					Return(clickStats, tc.mockError).Once()
+			}
+
+			r := chi.NewRouter()
+			r.Get("/url/{alias}/stats", stats.New(slogdiscard.NewDiscardLogger(), clickStatsGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, "/url/abc/stats"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var resp stats.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, int64(10), resp.Total)
+				require.Equal(t, int64(4), resp.Unique)
+				require.Len(t, resp.Buckets, 2)
+				require.Equal(t, from, resp.Buckets[0].Start)
+				require.Equal(t, int64(3), resp.Buckets[0].Clicks)
+			}
+		})
+	}
+}
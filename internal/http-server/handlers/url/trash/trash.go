@@ -0,0 +1,190 @@
+package trash
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+const defaultLimit = 20
+
+type URL struct {
+	Alias     string    `json:"alias"`
+	URL       string    `json:"url"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type ListResponse struct {
+	resp.Response
+	URLs []URL `json:"urls"`
+}
+
+type PurgeResponse struct {
+	resp.Response
+	Purged int64 `json:"purged"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLTrash
+type URLTrash interface {
+	ListDeletedURLs(limit int, offset int) ([]storage.URL, error)
+	UndeleteURL(alias string) error
+	PurgeURLs(before time.Time) (int64, error)
+}
+
+// New lists the urls in the trash, most recently deleted first.
+func New(log *slog.Logger, urlTrash URLTrash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.trash.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, offset, err := parsePage(r)
+		if err != nil {
+			log.Info("invalid page", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		urls, err := urlTrash.ListDeletedURLs(limit, offset)
+		if err != nil {
+			log.Error("failed to list deleted urls", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("listed deleted urls", slog.Int("count", len(urls)))
+
+		res := ListResponse{
+			Response: resp.OK(),
+			URLs:     make([]URL, 0, len(urls)),
+		}
+
+		for _, u := range urls {
+			res.URLs = append(res.URLs, URL{Alias: u.Alias, URL: u.URL, DeletedAt: u.DeletedAt})
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+// NewRestore takes the url with the alias URL parameter out of the trash.
+func NewRestore(log *slog.Logger, urlTrash URLTrash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.trash.NewRestore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias := chi.URLParam(r, "alias")
+		if alias == "" {
+			log.Info("alias is empty")
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		err := urlTrash.UndeleteURL(alias)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Info("url not in trash", slog.String("alias", alias))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore url", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("url restored", slog.String("alias", alias))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
+
+// NewPurge permanently deletes the urls that have been in the trash for
+// longer than the olderThan query parameter, a duration such as 720h.
+func NewPurge(log *slog.Logger, urlTrash URLTrash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.trash.NewPurge"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		olderThan, err := time.ParseDuration(r.URL.Query().Get("olderThan"))
+		if err != nil || olderThan < 0 {
+			log.Info("invalid olderThan", slog.String("olderThan", r.URL.Query().Get("olderThan")))
+
+			render.JSON(w, r, resp.Error("invalid request"))
+
+			return
+		}
+
+		purged, err := urlTrash.PurgeURLs(time.Now().Add(-olderThan))
+		if err != nil {
+			log.Error("failed to purge urls", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("urls purged", slog.Int64("count", purged))
+
+		render.JSON(w, r, PurgeResponse{
+			Response: resp.OK(),
+			Purged:   purged,
+		})
+	}
+}
+
+func parsePage(r *http.Request) (int, int, error) {
+	limit, offset := defaultLimit, 0
+
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = n
+	}
+
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = n
+	}
+
+	return limit, offset, nil
+}
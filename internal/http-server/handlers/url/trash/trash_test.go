@@ -0,0 +1,154 @@
+package trash_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/http-server/handlers/url/trash"
+	"go-api/internal/http-server/handlers/url/trash/mocks"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+func TestListHandler(t *testing.T) {
+	urlTrashMock := mocks.NewURLTrash(t)
+
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	urlTrashMock.On("ListDeletedURLs", 20, 0).
+		Return([]storage.URL{{ID: 1, Alias: "go", URL: "https://go.dev", DeletedAt: deletedAt}}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, "/url/trash", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	trash.New(slogdiscard.NewDiscardLogger(), urlTrashMock).ServeHTTP(rr, req)
+
+	var res trash.ListResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Empty(t, res.Error)
+	require.Equal(t, []trash.URL{{Alias: "go", URL: "https://go.dev", DeletedAt: deletedAt}}, res.URLs)
+}
+
+func TestRestoreHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		respError string
+		mockError error
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name:      "Not in trash",
+			respError: "not found",
+			mockError: storage.ErrURLNotFound,
+		},
+		{
+			name:      "UndeleteURL Error",
+			respError: "internal error",
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlTrashMock := mocks.NewURLTrash(t)
+
+			urlTrashMock.On("UndeleteURL", "go").
+				Return(tc.mockError).Once()
+
+			r := chi.NewRouter()
+			r.Post("/url/{alias}/restore", trash.NewRestore(slogdiscard.NewDiscardLogger(), urlTrashMock))
+
+			req, err := http.NewRequest(http.MethodPost, "/url/go/restore", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, rr.Code, http.StatusOK)
+
+			var res resp.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+			require.Equal(t, tc.respError, res.Error)
+		})
+	}
+}
+
+func TestPurgeHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		olderThan string
+		respError string
+	}{
+		{
+			name:      "Success",
+			olderThan: "720h",
+		},
+		{
+			name:      "Everything",
+			olderThan: "0s",
+		},
+		{
+			name:      "Missing",
+			respError: "invalid request",
+		},
+		{
+			name:      "Negative",
+			olderThan: "-1h",
+			respError: "invalid request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlTrashMock := mocks.NewURLTrash(t)
+
+			if tc.respError == "" {
+				olderThan, err := time.ParseDuration(tc.olderThan)
+				require.NoError(t, err)
+
+				urlTrashMock.On("PurgeURLs", mock.MatchedBy(func(before time.Time) bool {
+					return time.Since(before) >= olderThan && time.Since(before) < olderThan+time.Minute
+				})).Return(int64(3), nil).Once()
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, "/url/trash?olderThan="+tc.olderThan, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			trash.NewPurge(slogdiscard.NewDiscardLogger(), urlTrashMock).ServeHTTP(rr, req)
+
+			var res trash.PurgeResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+			require.Equal(t, tc.respError, res.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, int64(3), res.Purged)
+			}
+		})
+	}
+}
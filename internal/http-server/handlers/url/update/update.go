@@ -0,0 +1,276 @@
+package update
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"go-api/internal/http-server/handlers/url/save"
+	"go-api/internal/lib/api/etag"
+	resp "go-api/internal/lib/api/response"
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/lib/mergepatch"
+	"go-api/internal/storage"
+)
+
+var ErrAliasMismatch = errors.New("alias can not be changed")
+
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --name=URLUpdater
+type URLUpdater interface {
+	LookupURL(alias string) (storage.URL, error)
+	UpdateURL(url storage.URL, version int64, actor string) (storage.URL, error)
+}
+
+// New replaces the url with the request body (PUT). Without a password in
+// the body the url is no longer protected.
+func New(log *slog.Logger, urlUpdater URLUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.update.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias, version, ok := parseTarget(w, r, log)
+		if !ok {
+			return
+		}
+
+		var req save.Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		url, ok := validate(w, r, log, alias, req)
+		if !ok {
+			return
+		}
+
+		update(w, r, log, urlUpdater, url, version)
+	}
+}
+
+// NewPatch applies a JSON merge patch (RFC 7386) to the url (PATCH). The
+// password is kept unless the patch sets it, or removes it with null.
+func NewPatch(log *slog.Logger, urlUpdater URLUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.update.NewPatch"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		alias, version, ok := parseTarget(w, r, log)
+		if !ok {
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		current, err := urlUpdater.LookupURL(alias)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Info("url not found", slog.String("alias", alias))
+
+			render.JSON(w, r, resp.Error("not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get url", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		if version != 0 && current.Version != version {
+			log.Info("url version mismatch", slog.String("alias", alias), slog.Int64("version", current.Version))
+
+			w.Header().Set("ETag", etag.Format(current.Version))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, resp.Error("url was modified"))
+
+			return
+		}
+
+		req, keepPassword, keepExpiry, err := applyPatch(current, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.JSON(w, r, resp.Error("failed to decode request"))
+
+			return
+		}
+
+		log.Info("patch applied", slog.Any("request", req))
+
+		url, ok := validate(w, r, log, alias, req)
+		if !ok {
+			return
+		}
+
+		if keepPassword {
+			url.PasswordHash = current.PasswordHash
+		}
+
+		// an expiry the patch leaves alone is kept even if it has passed,
+		// which a new one may not have
+		if keepExpiry {
+			url.ExpiresAt = current.ExpiresAt
+		}
+
+		// the url is checked against the version it was patched from
+		update(w, r, log, urlUpdater, url, current.Version)
+	}
+}
+
+// parseTarget reads the alias and the optional If-Match version, writing
+// an error response if the version is malformed. The version is 0 without
+// If-Match.
+func parseTarget(w http.ResponseWriter, r *http.Request, log *slog.Logger) (string, int64, bool) {
+	alias := chi.URLParam(r, "alias")
+	if alias == "" {
+		log.Info("alias is empty")
+
+		render.JSON(w, r, resp.Error("invalid request"))
+
+		return "", 0, false
+	}
+
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return alias, 0, true
+	}
+
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		log.Info("invalid If-Match header", sl.Err(err))
+
+		render.Status(r, http.StatusPreconditionFailed)
+		render.JSON(w, r, resp.Error("invalid If-Match header"))
+
+		return "", 0, false
+	}
+
+	return alias, version, true
+}
+
+// validate checks the request like save does; the alias of the request, if
+// any, must be that of the url.
+func validate(w http.ResponseWriter, r *http.Request, log *slog.Logger, alias string, req save.Request) (storage.URL, bool) {
+	if req.Alias != "" && req.Alias != alias {
+		log.Info("invalid request", sl.Err(ErrAliasMismatch))
+
+		render.JSON(w, r, resp.Error(ErrAliasMismatch.Error()))
+
+		return storage.URL{}, false
+	}
+
+	url, err := save.Validate(req)
+	if err != nil {
+		log.Error("invalid request", sl.Err(err))
+
+		render.JSON(w, r, save.ValidationResponse(err))
+
+		return storage.URL{}, false
+	}
+
+	url.Alias = alias
+
+	return url, true
+}
+
+func update(w http.ResponseWriter, r *http.Request, log *slog.Logger, urlUpdater URLUpdater, url storage.URL, version int64) {
+	actor, _, _ := r.BasicAuth()
+
+	updated, err := urlUpdater.UpdateURL(url, version, actor)
+	if errors.Is(err, storage.ErrURLNotFound) {
+		log.Info("url not found", slog.String("alias", url.Alias))
+
+		render.JSON(w, r, resp.Error("not found"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrURLVersionMismatch) {
+		log.Info("url version mismatch", slog.String("alias", url.Alias))
+
+		render.Status(r, http.StatusPreconditionFailed)
+		render.JSON(w, r, resp.Error("url was modified"))
+
+		return
+	}
+	if err != nil {
+		log.Error("failed to update url", sl.Err(err))
+
+		render.JSON(w, r, resp.Error("failed to update url"))
+
+		return
+	}
+
+	log.Info("url updated", slog.String("alias", url.Alias), slog.Int64("version", updated.Version))
+
+	w.Header().Set("ETag", etag.Format(updated.Version))
+
+	render.JSON(w, r, save.ToResponse(updated))
+}
+
+// applyPatch merges the patch into the request the url was saved with. It
+// also reports whether the patch leaves the password alone, since only its
+// hash is kept, and whether it leaves the expiry alone: the expiry is only
+// in the request if the patch sets it, so that it is only validated then.
+func applyPatch(current storage.URL, patch []byte) (save.Request, bool, bool, error) {
+	req := save.Request{
+		URL:       current.URL,
+		MaxClicks: current.MaxClicks,
+	}
+
+	doc, err := json.Marshal(req)
+	if err != nil {
+		return save.Request{}, false, false, err
+	}
+
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return save.Request{}, false, false, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return save.Request{}, false, false, err
+	}
+
+	_, hasPassword := fields["password"]
+	_, hasExpiry := fields["expires_at"]
+
+	req = save.Request{}
+
+	if err := json.Unmarshal(merged, &req); err != nil {
+		return save.Request{}, false, false, err
+	}
+
+	return req, !hasPassword, !hasExpiry, nil
+}
@@ -0,0 +1,360 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-api/internal/http-server/handlers/url/save"
+	"go-api/internal/http-server/handlers/url/update"
+	"go-api/internal/http-server/handlers/url/update/mocks"
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+var current = storage.URL{
+	ID:           1,
+	Alias:        "docs",
+	URL:          "https://example.com/v1",
+	MaxClicks:    100,
+	ClickCount:   7,
+	PasswordHash: "$2a$10$hash",
+	Version:      3,
+}
+
+func TestPutHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		ifMatch   string
+		body      string
+		code      int
+		version   int64
+		etag      string
+		respError string
+		mockError error
+	}{
+		{
+			name:    "Success",
+			ifMatch: `"3"`,
+			body:    `{"url":"https://example.com/v2"}`,
+			code:    http.StatusOK,
+			version: 3,
+			etag:    `"4"`,
+		},
+		{
+			name:    "Without If-Match",
+			body:    `{"url":"https://example.com/v2","alias":"docs"}`,
+			code:    http.StatusOK,
+			version: 0,
+			etag:    `"1"`,
+		},
+		{
+			name:      "Stale version",
+			ifMatch:   `"2"`,
+			body:      `{"url":"https://example.com/v2"}`,
+			code:      http.StatusPreconditionFailed,
+			version:   2,
+			respError: "url was modified",
+			mockError: storage.ErrURLVersionMismatch,
+		},
+		{
+			name:      "Invalid If-Match",
+			ifMatch:   `W/"x"`,
+			body:      `{"url":"https://example.com/v2"}`,
+			code:      http.StatusPreconditionFailed,
+			respError: "invalid If-Match header",
+		},
+		{
+			name:      "Not found",
+			body:      `{"url":"https://example.com/v2"}`,
+			code:      http.StatusOK,
+			respError: "not found",
+			mockError: storage.ErrURLNotFound,
+		},
+		{
+			name:      "Invalid URL",
+			body:      `{"url":"some invalid URL"}`,
+			code:      http.StatusOK,
+			respError: "field URL is not a valid URL",
+		},
+		{
+			name:      "Expired",
+			body:      `{"url":"https://example.com/v2","expires_at":"2000-01-01T00:00:00Z"}`,
+			code:      http.StatusOK,
+			respError: save.ErrExpiresInPast.Error(),
+		},
+		{
+			name:      "Other alias",
+			body:      `{"url":"https://example.com/v2","alias":"blog"}`,
+			code:      http.StatusOK,
+			respError: update.ErrAliasMismatch.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlUpdaterMock := mocks.NewURLUpdater(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				urlUpdaterMock.On("UpdateURL", mock.MatchedBy(func(u storage.URL) bool {
+					return u.Alias == "docs" && u.URL == "https://example.com/v2" && u.PasswordHash == ""
+				}), tc.version, "admin").
+					Return(func(u storage.URL, version int64, actor string) (storage.URL, error) {
+						u.Version = version + 1
+
+						return u, tc.mockError
+					}).Once()
+			}
+
+			rr := serve(t, http.MethodPut, update.New(slogdiscard.NewDiscardLogger(), urlUpdaterMock), tc.ifMatch, tc.body)
+
+			require.Equal(t, tc.code, rr.Code)
+			require.Equal(t, tc.etag, rr.Header().Get("ETag"))
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func TestPatchHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		ifMatch   string
+		body      string
+		code      int
+		want      storage.URL
+		password  string
+		respError string
+	}{
+		{
+			name:    "Change target",
+			ifMatch: `"3"`,
+			body:    `{"url":"https://example.com/v2"}`,
+			code:    http.StatusOK,
+			want: storage.URL{
+				Alias: "docs", URL: "https://example.com/v2", MaxClicks: 100, PasswordHash: current.PasswordHash,
+			},
+		},
+		{
+			name: "Remove click limit and password",
+			body: `{"max_clicks":null,"password":null}`,
+			code: http.StatusOK,
+			want: storage.URL{
+				Alias: "docs", URL: "https://example.com/v1",
+			},
+		},
+		{
+			name:     "Change password",
+			body:     `{"password":"n3w-secret"}`,
+			code:     http.StatusOK,
+			password: "n3w-secret",
+		},
+		{
+			name:      "Remove required field",
+			body:      `{"url":null}`,
+			code:      http.StatusOK,
+			respError: "field URL is a required field",
+		},
+		{
+			name:      "Stale version",
+			ifMatch:   `"1"`,
+			body:      `{"url":"https://example.com/v2"}`,
+			code:      http.StatusPreconditionFailed,
+			respError: "url was modified",
+		},
+		{
+			name:      "Not an object",
+			body:      `["url"]`,
+			code:      http.StatusOK,
+			respError: "failed to decode request",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlUpdaterMock := mocks.NewURLUpdater(t)
+
+			urlUpdaterMock.On("LookupURL", "docs").
+				Return(current, nil).Once()
+
+			if tc.respError == "" {
+				var want interface{} = tc.want
+				if tc.password != "" {
+					want = mock.MatchedBy(func(u storage.URL) bool {
+						return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(tc.password)) == nil
+					})
+				}
+
+				urlUpdaterMock.On("UpdateURL", want, current.Version, "admin").
+					Return(func(u storage.URL, version int64, actor string) (storage.URL, error) {
+						u.Version = version + 1
+
+						return u, nil
+					}).Once()
+			}
+
+			rr := serve(t, http.MethodPatch, update.NewPatch(slogdiscard.NewDiscardLogger(), urlUpdaterMock), tc.ifMatch, tc.body)
+
+			require.Equal(t, tc.code, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, `"4"`, rr.Header().Get("ETag"))
+				require.Equal(t, current.Version+1, resp.Version)
+			}
+		})
+	}
+}
+
+func TestPatchExpiredURL(t *testing.T) {
+	expired := current
+	expired.ExpiresAt = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		body      string
+		want      storage.URL
+		respError string
+	}{
+		{
+			name: "Keep expiry",
+			body: `{"url":"https://example.com/v2"}`,
+			want: storage.URL{
+				Alias: "docs", URL: "https://example.com/v2", ExpiresAt: expired.ExpiresAt, MaxClicks: 100,
+				PasswordHash: current.PasswordHash,
+			},
+		},
+		{
+			name: "Remove expiry",
+			body: `{"expires_at":null}`,
+			want: storage.URL{
+				Alias: "docs", URL: "https://example.com/v1", MaxClicks: 100, PasswordHash: current.PasswordHash,
+			},
+		},
+		{
+			name:      "Set expiry in the past",
+			body:      `{"expires_at":"2001-01-01T00:00:00Z"}`,
+			respError: save.ErrExpiresInPast.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlUpdaterMock := mocks.NewURLUpdater(t)
+
+			urlUpdaterMock.On("LookupURL", "docs").
+				Return(expired, nil).Once()
+
+			if tc.respError == "" {
+				urlUpdaterMock.On("UpdateURL", tc.want, current.Version, "admin").
+					Return(func(u storage.URL, version int64, actor string) (storage.URL, error) {
+						u.Version = version + 1
+
+						return u, nil
+					}).Once()
+			}
+
+			rr := serve(t, http.MethodPatch, update.NewPatch(slogdiscard.NewDiscardLogger(), urlUpdaterMock), "", tc.body)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func TestHandlersRedactPassword(t *testing.T) {
+	cases := []struct {
+		name    string
+		method  string
+		handler func(log *slog.Logger, urlUpdater update.URLUpdater) http.HandlerFunc
+	}{
+		{name: "Put", method: http.MethodPut, handler: update.New},
+		{name: "Patch", method: http.MethodPatch, handler: update.NewPatch},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			urlUpdaterMock := mocks.NewURLUpdater(t)
+
+			urlUpdaterMock.On("LookupURL", "docs").
+				Return(current, nil).Maybe()
+
+			urlUpdaterMock.On("UpdateURL", mock.AnythingOfType("storage.URL"), mock.AnythingOfType("int64"), "admin").
+				Return(func(u storage.URL, version int64, actor string) (storage.URL, error) {
+					u.Version = current.Version + 1
+
+					return u, nil
+				}).Once()
+
+			var logs bytes.Buffer
+
+			handler := tc.handler(slog.New(slog.NewJSONHandler(&logs, nil)), urlUpdaterMock)
+
+			rr := serve(t, tc.method, handler, "", `{"url":"https://example.com/v2","password":"n3w-secret"}`)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Contains(t, logs.String(), `"password":"REDACTED"`)
+			require.NotContains(t, logs.String(), "n3w-secret")
+		})
+	}
+}
+
+func serve(t *testing.T, method string, handler http.HandlerFunc, ifMatch string, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.MethodFunc(method, "/url/{alias}", handler)
+
+	req, err := http.NewRequest(method, "/url/docs", bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	req.SetBasicAuth("admin", "secret")
+
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,98 @@
+// Package alias implements the strategies for generating the aliases of
+// urls saved without one. Every strategy is given the id the url is to be
+// saved with, which only some of them use.
+package alias
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"go-api/internal/lib/random"
+)
+
+const base62Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Random makes aliases of random characters, ignoring the id.
+type Random struct {
+	generator *random.Generator
+}
+
+func NewRandom(length int, alphabet string) (*Random, error) {
+	g, err := random.New(length, alphabet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Random{generator: g}, nil
+}
+
+func (r *Random) Generate(int64) (string, error) {
+	return r.generator.Generate()
+}
+
+// Base62 makes the shortest aliases: the id in base 62.
+type Base62 struct{}
+
+func (Base62) Generate(id int64) (string, error) {
+	if id < 0 {
+		return "", ErrNegativeID
+	}
+
+	return string(encode(id, []byte(base62Alphabet))), nil
+}
+
+// Words makes readable aliases of a random adjective, noun and two digit
+// number, like brave-otter-42, ignoring the id.
+type Words struct{}
+
+func (Words) Generate(int64) (string, error) {
+	adjective, err := pick(len(adjectives))
+	if err != nil {
+		return "", err
+	}
+
+	noun, err := pick(len(nouns))
+	if err != nil {
+		return "", err
+	}
+
+	n, err := pick(100)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s-%02d", adjectives[adjective], nouns[noun], n), nil
+}
+
+// pick returns a random number in [0, n).
+func pick(n int) (int, error) {
+	k, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(k.Int64()), nil
+}
+
+var adjectives = []string{
+	"amber", "bold", "brave", "bright", "brisk", "calm", "clever", "cosy",
+	"crisp", "curly", "daring", "eager", "fancy", "fast", "fluffy", "gentle",
+	"giant", "glad", "golden", "grand", "happy", "hidden", "humble", "jolly",
+	"keen", "kind", "lively", "lucky", "merry", "mighty", "misty", "noble",
+	"odd", "plain", "polite", "proud", "quick", "quiet", "rapid", "rosy",
+	"rusty", "shiny", "silent", "silver", "sleepy", "smooth", "snowy", "solid",
+	"spicy", "steady", "sunny", "swift", "tall", "tidy", "tiny", "vast",
+	"velvet", "vivid", "warm", "wild", "wise", "witty", "young", "zesty",
+}
+
+var nouns = []string{
+	"badger", "bear", "beaver", "bison", "cactus", "camel", "cedar", "cloud",
+	"comet", "coral", "crane", "daisy", "dolphin", "eagle", "falcon", "fern",
+	"finch", "fox", "gecko", "glacier", "harbor", "hawk", "heron", "island",
+	"koala", "lagoon", "lemur", "lion", "lotus", "lynx", "maple", "meadow",
+	"moose", "moth", "otter", "owl", "panda", "pebble", "pine", "planet",
+	"puffin", "rabbit", "raven", "river", "robin", "salmon", "seal", "sparrow",
+	"spruce", "squid", "star", "stone", "swan", "tiger", "tulip", "turtle",
+	"valley", "walrus", "willow", "wolf", "wombat", "yak", "zebra", "zephyr",
+}
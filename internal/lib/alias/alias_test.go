@@ -0,0 +1,86 @@
+package alias
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase62(t *testing.T) {
+	cases := []struct {
+		id    int64
+		alias string
+	}{
+		{id: 0, alias: "0"},
+		{id: 9, alias: "9"},
+		{id: 10, alias: "a"},
+		{id: 61, alias: "Z"},
+		{id: 62, alias: "10"},
+		{id: 3843, alias: "ZZ"},
+		{id: 1000000, alias: "4c92"},
+	}
+
+	for _, tc := range cases {
+		alias, err := Base62{}.Generate(tc.id)
+		require.NoError(t, err)
+		require.Equal(t, tc.alias, alias)
+	}
+
+	_, err := Base62{}.Generate(-1)
+	require.ErrorIs(t, err, ErrNegativeID)
+}
+
+func TestHashids(t *testing.T) {
+	cases := []struct {
+		salt      string
+		minLength int
+		id        int64
+		alias     string
+	}{
+		{salt: "this is my salt", id: 12345, alias: "NkK9"},
+		{salt: "this is my salt", minLength: 8, id: 1, alias: "gB0NV05e"},
+		{salt: "this is my salt", minLength: 8, id: 12345, alias: "B0NkK9A5"},
+		{id: 1, alias: "jR"},
+	}
+
+	for _, tc := range cases {
+		alias, err := NewHashids(tc.salt, tc.minLength).Generate(tc.id)
+		require.NoError(t, err)
+		require.Equal(t, tc.alias, alias)
+	}
+
+	a, err := NewHashids("one salt", 6).Generate(7)
+	require.NoError(t, err)
+
+	b, err := NewHashids("another salt", 6).Generate(7)
+	require.NoError(t, err)
+
+	require.NotEqual(t, a, b)
+}
+
+func TestRandom(t *testing.T) {
+	r, err := NewRandom(10, "xyz")
+	require.NoError(t, err)
+
+	alias, err := r.Generate(1)
+	require.NoError(t, err)
+	require.Regexp(t, `^[xyz]{10}$`, alias)
+
+	_, err = NewRandom(0, "xyz")
+	require.Error(t, err)
+}
+
+func TestWords(t *testing.T) {
+	re := regexp.MustCompile(`^([a-z]+)-([a-z]+)-[0-9]{2}$`)
+
+	for i := 0; i < 20; i++ {
+		alias, err := Words{}.Generate(int64(i))
+		require.NoError(t, err)
+
+		m := re.FindStringSubmatch(alias)
+		require.NotNil(t, m, alias)
+		require.Contains(t, adjectives, m[1])
+		require.Contains(t, nouns, m[2])
+	}
+}
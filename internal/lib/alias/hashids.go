@@ -0,0 +1,154 @@
+package alias
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	hashidsAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+	hashidsSeps     = "cfhistuCFHISTU"
+)
+
+var ErrNegativeID = errors.New("id must not be negative")
+
+// Hashids makes aliases from the ids of urls with the hashids algorithm
+// (https://hashids.org), so that the aliases do not give away how many
+// urls there are. Ids are encoded like other hashids implementations do
+// with the same salt and minimum length.
+type Hashids struct {
+	salt      []byte
+	minLength int
+	alphabet  []byte
+	seps      []byte
+	guards    []byte
+}
+
+func NewHashids(salt string, minLength int) *Hashids {
+	h := &Hashids{salt: []byte(salt), minLength: minLength}
+
+	alphabet := []byte(hashidsAlphabet)
+	seps := []byte(hashidsSeps)
+
+	// the separators are taken out of the alphabet
+	alphabet = []byte(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(hashidsSeps, r) {
+			return -1
+		}
+
+		return r
+	}, string(alphabet)))
+
+	shuffle(seps, h.salt)
+
+	if len(seps) == 0 || float64(len(alphabet))/float64(len(seps)) > 3.5 {
+		sepsLength := (len(alphabet) + 3) * 2 / 7
+		if sepsLength == 1 {
+			sepsLength++
+		}
+
+		if sepsLength > len(seps) {
+			diff := sepsLength - len(seps)
+			seps = append(seps, alphabet[:diff]...)
+			alphabet = alphabet[diff:]
+		} else {
+			seps = seps[:sepsLength]
+		}
+	}
+
+	shuffle(alphabet, h.salt)
+
+	guardCount := (len(alphabet) + 11) / 12
+	if len(alphabet) < 3 {
+		h.guards, seps = seps[:guardCount], seps[guardCount:]
+	} else {
+		h.guards, alphabet = alphabet[:guardCount], alphabet[guardCount:]
+	}
+
+	h.alphabet, h.seps = alphabet, seps
+
+	return h
+}
+
+// Generate returns the hashid of the id.
+func (h *Hashids) Generate(id int64) (string, error) {
+	if id < 0 {
+		return "", ErrNegativeID
+	}
+
+	alphabet := append([]byte(nil), h.alphabet...)
+
+	numbersHash := id % 100
+	lottery := alphabet[numbersHash%int64(len(alphabet))]
+
+	buffer := make([]byte, 0, 1+len(h.salt)+len(alphabet))
+	buffer = append(buffer, lottery)
+	buffer = append(buffer, h.salt...)
+	buffer = append(buffer, alphabet...)
+
+	shuffle(alphabet, buffer[:len(alphabet)])
+
+	res := append([]byte{lottery}, encode(id, alphabet)...)
+
+	if len(res) < h.minLength {
+		guard := h.guards[(numbersHash+int64(res[0]))%int64(len(h.guards))]
+		res = append([]byte{guard}, res...)
+
+		if len(res) < h.minLength {
+			guard := h.guards[(numbersHash+int64(res[2]))%int64(len(h.guards))]
+			res = append(res, guard)
+		}
+	}
+
+	half := len(alphabet) / 2
+
+	for len(res) < h.minLength {
+		shuffle(alphabet, append([]byte(nil), alphabet...))
+
+		padded := make([]byte, 0, len(alphabet)+len(res))
+		padded = append(padded, alphabet[half:]...)
+		padded = append(padded, res...)
+		padded = append(padded, alphabet[:half]...)
+		res = padded
+
+		if excess := len(res) - h.minLength; excess > 0 {
+			res = res[excess/2 : excess/2+h.minLength]
+		}
+	}
+
+	return string(res), nil
+}
+
+// encode writes n in the base of the alphabet, whose characters are the
+// digits.
+func encode(n int64, alphabet []byte) []byte {
+	base := int64(len(alphabet))
+
+	var res []byte
+	for {
+		res = append([]byte{alphabet[n%base]}, res...)
+
+		n /= base
+		if n == 0 {
+			return res
+		}
+	}
+}
+
+// shuffle reorders the alphabet in place, always in the same way for the
+// same salt.
+func shuffle(alphabet []byte, salt []byte) {
+	if len(salt) == 0 {
+		return
+	}
+
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+
+		n := int(salt[v])
+		p += n
+
+		j := (n + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
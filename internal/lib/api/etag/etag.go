@@ -0,0 +1,31 @@
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidETag = errors.New("invalid etag")
+
+// Format returns a strong entity tag for a row version.
+func Format(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// Parse extracts the row version from an If-Match header value.
+// Weak tags are accepted as well, since versions are compared exactly.
+func Parse(header string) (int64, error) {
+	tag := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, ErrInvalidETag
+	}
+
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidETag
+	}
+
+	return version, nil
+}
@@ -35,7 +35,7 @@ func ValidationError(errs validator.ValidationErrors) Response {
 
 	for _, err := range errs {
 		switch err.ActualTag() {
-		case "required":
+		case "required", "required_without", "required_if":
 			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
 
 		case "url":
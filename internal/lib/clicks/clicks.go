@@ -0,0 +1,136 @@
+// Package clicks records redirects in the background, so that writing
+// them to the storage does not slow down the redirects.
+package clicks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"go-api/internal/lib/logger/sl"
+	"go-api/internal/storage"
+)
+
+type ClickSaver interface {
+	SaveClicks(clicks []storage.Click) error
+}
+
+// Recorder buffers clicks and writes them in batches. A click recorded
+// while the buffer is full is dropped rather than waited for.
+type Recorder struct {
+	log           *slog.Logger
+	clickSaver    ClickSaver
+	salt          []byte
+	batchSize     int
+	flushInterval time.Duration
+	clicks        chan storage.Click
+	done          chan struct{}
+}
+
+// New starts a Recorder writing batches of up to batchSize clicks, at
+// least every flushInterval. Visitor IPs are hashed with salt as the key.
+func New(
+	log *slog.Logger,
+	clickSaver ClickSaver,
+	bufferSize int,
+	batchSize int,
+	flushInterval time.Duration,
+	salt string,
+) *Recorder {
+	rec := &Recorder{
+		log:           log.With(slog.String("op", "lib.clicks.Recorder")),
+		clickSaver:    clickSaver,
+		salt:          []byte(salt),
+		batchSize:     max(batchSize, 1),
+		flushInterval: flushInterval,
+		clicks:        make(chan storage.Click, bufferSize),
+		done:          make(chan struct{}),
+	}
+
+	go rec.run()
+
+	return rec
+}
+
+// Record queues a click on alias made by the request.
+func (rec *Recorder) Record(alias string, r *http.Request) {
+	click := storage.Click{
+		Alias:     alias,
+		At:        time.Now(),
+		Referrer:  r.Referer(),
+		UserAgent: r.UserAgent(),
+		IPHash:    rec.hashIP(r.RemoteAddr),
+	}
+
+	select {
+	case rec.clicks <- click:
+	default:
+		rec.log.Warn("click buffer is full, click dropped", slog.String("alias", alias))
+	}
+}
+
+// Close writes the queued clicks and stops the Recorder. Nothing may be
+// recorded after Close.
+func (rec *Recorder) Close() {
+	close(rec.clicks)
+	<-rec.done
+}
+
+func (rec *Recorder) run() {
+	defer close(rec.done)
+
+	ticker := time.NewTicker(rec.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]storage.Click, 0, rec.batchSize)
+
+	for {
+		select {
+		case click, ok := <-rec.clicks:
+			if !ok {
+				rec.flush(batch)
+
+				return
+			}
+
+			batch = append(batch, click)
+
+			if len(batch) >= rec.batchSize {
+				batch = rec.flush(batch)
+			}
+		case <-ticker.C:
+			batch = rec.flush(batch)
+		}
+	}
+}
+
+// flush writes the batch and returns it emptied. A batch that fails to
+// be written is lost, as retrying would hold up the clicks after it.
+func (rec *Recorder) flush(batch []storage.Click) []storage.Click {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	if err := rec.clickSaver.SaveClicks(batch); err != nil {
+		rec.log.Error("failed to save clicks", slog.Int("count", len(batch)), sl.Err(err))
+	}
+
+	return batch[:0]
+}
+
+// hashIP hashes the host part of addr; RemoteAddr has a port unless it
+// was rewritten by the RealIP middleware.
+func (rec *Recorder) hashIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	mac := hmac.New(sha256.New, rec.salt)
+	mac.Write([]byte(addr))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
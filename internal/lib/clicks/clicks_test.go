@@ -0,0 +1,71 @@
+package clicks
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+	"go-api/internal/storage"
+)
+
+type saver struct {
+	mu      sync.Mutex
+	batches [][]storage.Click
+}
+
+func (s *saver) SaveClicks(clicks []storage.Click) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, append([]storage.Click(nil), clicks...))
+
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	s := &saver{}
+	rec := New(slogdiscard.NewDiscardLogger(), s, 10, 2, time.Hour, "salt")
+
+	for _, addr := range []string{"192.0.2.1:1234", "192.0.2.1:5678", "198.51.100.7"} {
+		r := httptest.NewRequest("GET", "/abc", nil)
+		r.RemoteAddr = addr
+		r.Header.Set("Referer", "https://example.com/")
+		r.Header.Set("User-Agent", "test")
+
+		rec.Record("abc", r)
+	}
+
+	rec.Close()
+
+	require.Len(t, s.batches, 2)
+	require.Len(t, s.batches[0], 2)
+	require.Len(t, s.batches[1], 1)
+
+	first, second, third := s.batches[0][0], s.batches[0][1], s.batches[1][0]
+
+	require.Equal(t, "abc", first.Alias)
+	require.Equal(t, "https://example.com/", first.Referrer)
+	require.Equal(t, "test", first.UserAgent)
+	require.Equal(t, first.IPHash, second.IPHash)
+	require.NotEqual(t, first.IPHash, third.IPHash)
+	require.NotContains(t, first.IPHash, "192.0.2.1")
+}
+
+func TestRecorderFlushInterval(t *testing.T) {
+	s := &saver{}
+	rec := New(slogdiscard.NewDiscardLogger(), s, 10, 100, 10*time.Millisecond, "")
+	defer rec.Close()
+
+	rec.Record("abc", httptest.NewRequest("GET", "/abc", nil))
+
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		return len(s.batches) == 1
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,62 @@
+// Package discount splits promotion discounts over amounts in minor units
+// so that the discounts of the parts always add up to the whole.
+package discount
+
+import "math/big"
+
+// Percent returns the discount of percent on each amount, rounded half up.
+func Percent(amounts []int64, percent int64) []int64 {
+	discounts := make([]int64, len(amounts))
+
+	for i, amount := range amounts {
+		discounts[i] = share(amount, percent, 100, true)
+	}
+
+	return discounts
+}
+
+// Fixed spreads amount over amounts in proportion to them. The discount
+// never exceeds the sum of amounts; cents lost to rounding go to the
+// first amounts that can still take them.
+func Fixed(amounts []int64, amount int64) []int64 {
+	discounts := make([]int64, len(amounts))
+
+	var sum int64
+	for _, a := range amounts {
+		sum += a
+	}
+
+	if sum <= 0 || amount <= 0 {
+		return discounts
+	}
+	if amount > sum {
+		amount = sum
+	}
+
+	left := amount
+
+	for i, a := range amounts {
+		discounts[i] = share(a, amount, sum, false)
+		left -= discounts[i]
+	}
+
+	for i := 0; left > 0 && i < len(amounts); i++ {
+		if discounts[i] < amounts[i] {
+			discounts[i]++
+			left--
+		}
+	}
+
+	return discounts
+}
+
+// share is amount * num / den without overflow, rounded half up or down.
+func share(amount, num, den int64, roundUp bool) int64 {
+	n := new(big.Int).Mul(big.NewInt(amount), big.NewInt(num))
+
+	if roundUp {
+		n.Add(n, big.NewInt(den/2))
+	}
+
+	return n.Quo(n, big.NewInt(den)).Int64()
+}
@@ -0,0 +1,47 @@
+package discount_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/lib/discount"
+)
+
+func TestPercent(t *testing.T) {
+	require.Equal(t, []int64{100, 15, 0, 1}, discount.Percent([]int64{1000, 150, 0, 5}, 10))
+	require.Equal(t, []int64{999}, discount.Percent([]int64{999}, 100))
+}
+
+func TestFixed(t *testing.T) {
+	cases := []struct {
+		name    string
+		amounts []int64
+		amount  int64
+		want    []int64
+	}{
+		{name: "Proportional", amounts: []int64{3000, 1000}, amount: 400, want: []int64{300, 100}},
+		{name: "Rounding", amounts: []int64{100, 100, 100}, amount: 100, want: []int64{34, 33, 33}},
+		{name: "Capped", amounts: []int64{300, 200}, amount: 1000, want: []int64{300, 200}},
+		{name: "Nothing", amounts: []int64{0, 0}, amount: 100, want: []int64{0, 0}},
+		{name: "Empty", amounts: []int64{}, amount: 100, want: []int64{}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := discount.Fixed(tc.amounts, tc.amount)
+			require.Equal(t, tc.want, got)
+
+			var sum, total int64
+			for i := range got {
+				sum += got[i]
+				total += tc.amounts[i]
+			}
+			require.Equal(t, min(tc.amount, total), sum)
+		})
+	}
+}
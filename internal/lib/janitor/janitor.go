@@ -0,0 +1,82 @@
+// Package janitor removes expired short links in the background.
+package janitor
+
+import (
+	"log/slog"
+	"time"
+
+	"go-api/internal/lib/logger/sl"
+)
+
+type URLCleaner interface {
+	ArchiveExpiredURLs(now time.Time) (int64, error)
+	PurgeExpiredURLs(now time.Time) (int64, error)
+}
+
+// Janitor moves the expired urls to the trash, or purges them, every
+// interval.
+type Janitor struct {
+	log        *slog.Logger
+	urlCleaner URLCleaner
+	interval   time.Duration
+	purge      bool
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// New starts a Janitor. Expired urls are purged if purge is set and
+// archived to the trash otherwise, where they can still be restored.
+func New(log *slog.Logger, urlCleaner URLCleaner, interval time.Duration, purge bool) *Janitor {
+	j := &Janitor{
+		log:        log.With(slog.String("op", "lib.janitor.Janitor")),
+		urlCleaner: urlCleaner,
+		interval:   interval,
+		purge:      purge,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go j.run()
+
+	return j
+}
+
+// Close stops the Janitor, waiting for a clean up in progress.
+func (j *Janitor) Close() {
+	close(j.stop)
+	<-j.done
+}
+
+func (j *Janitor) run() {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			j.clean(now)
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+func (j *Janitor) clean(now time.Time) {
+	clean, action := j.urlCleaner.ArchiveExpiredURLs, "archived"
+	if j.purge {
+		clean, action = j.urlCleaner.PurgeExpiredURLs, "purged"
+	}
+
+	n, err := clean(now)
+	if err != nil {
+		j.log.Error("failed to clean up expired urls", sl.Err(err))
+
+		return
+	}
+
+	if n > 0 {
+		j.log.Info("expired urls "+action, slog.Int64("count", n))
+	}
+}
@@ -0,0 +1,57 @@
+package janitor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/lib/logger/handlers/slogdiscard"
+)
+
+type cleaner struct {
+	mu       sync.Mutex
+	archived int
+	purged   int
+}
+
+func (c *cleaner) ArchiveExpiredURLs(time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.archived++
+
+	return 1, nil
+}
+
+func (c *cleaner) PurgeExpiredURLs(time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.purged++
+
+	return 1, nil
+}
+
+func TestJanitor(t *testing.T) {
+	for _, purge := range []bool{false, true} {
+		c := &cleaner{}
+		j := New(slogdiscard.NewDiscardLogger(), c, 5*time.Millisecond, purge)
+
+		require.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			return c.archived+c.purged >= 2
+		}, time.Second, time.Millisecond)
+
+		j.Close()
+
+		if purge {
+			require.Zero(t, c.archived)
+		} else {
+			require.Zero(t, c.purged)
+		}
+	}
+}
@@ -49,13 +49,13 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	fields := make(map[string]interface{}, r.NumAttrs())
 
 	r.Attrs(func(a slog.Attr) bool {
-		fields[a.Key] = a.Value.Any()
+		fields[a.Key] = fieldValue(a.Value)
 
 		return true
 	})
 
 	for _, a := range h.attrs {
-		fields[a.Key] = a.Value.Any()
+		fields[a.Key] = fieldValue(a.Value)
 	}
 
 	var b []byte
@@ -81,6 +81,22 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	return nil
 }
 
+// fieldValue resolves values implementing slog.LogValuer, which may hide
+// secrets, and turns groups into maps so that they are marshaled by key.
+func fieldValue(v slog.Value) interface{} {
+	v = v.Resolve()
+	if v.Kind() != slog.KindGroup {
+		return v.Any()
+	}
+
+	group := make(map[string]interface{}, len(v.Group()))
+	for _, a := range v.Group() {
+		group[a.Key] = fieldValue(a.Value)
+	}
+
+	return group
+}
+
 func (h *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	return &PrettyHandler{
 		Handler: h.Handler,
@@ -0,0 +1,62 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrNotObject = errors.New("merge patch is not a JSON object")
+
+// Apply applies an RFC 7386 JSON merge patch to doc and returns the result.
+// Only object patches are accepted, since every patchable resource in the
+// API is a JSON object.
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	const op = "mergepatch.Apply"
+
+	var target map[string]any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	patchObj, ok := p.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotObject)
+	}
+
+	res, err := json.Marshal(merge(target, patchObj))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func merge(target any, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+
+			continue
+		}
+
+		targetObj[key] = merge(targetObj[key], value)
+	}
+
+	return targetObj
+}
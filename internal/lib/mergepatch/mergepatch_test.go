@@ -0,0 +1,74 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/lib/mergepatch"
+)
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+		err   error
+	}{
+		{
+			name:  "Replace value",
+			doc:   `{"a":"b"}`,
+			patch: `{"a":"c"}`,
+			want:  `{"a":"c"}`,
+		},
+		{
+			name:  "Add value",
+			doc:   `{"a":"b"}`,
+			patch: `{"b":"c"}`,
+			want:  `{"a":"b","b":"c"}`,
+		},
+		{
+			name:  "Remove value",
+			doc:   `{"a":"b","b":"c"}`,
+			patch: `{"a":null}`,
+			want:  `{"b":"c"}`,
+		},
+		{
+			name:  "Nested object",
+			doc:   `{"a":{"b":"c","d":"e"}}`,
+			patch: `{"a":{"d":null,"f":"g"}}`,
+			want:  `{"a":{"b":"c","f":"g"}}`,
+		},
+		{
+			name:  "Replace array",
+			doc:   `{"a":["b"]}`,
+			patch: `{"a":["c","d"]}`,
+			want:  `{"a":["c","d"]}`,
+		},
+		{
+			name:  "Not an object",
+			doc:   `{"a":"b"}`,
+			patch: `["c"]`,
+			err:   mergepatch.ErrNotObject,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := mergepatch.Apply([]byte(tc.doc), []byte(tc.patch))
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrTooPrecise      = errors.New("too many decimal places for currency")
+	ErrOverflow        = errors.New("amount is out of range")
+)
+
+// exponents holds the number of minor units digits of supported ISO 4217
+// currencies.
+var exponents = map[string]int{
+	"AED": 2, "ARS": 2, "AUD": 2, "AZN": 2, "BGN": 2, "BHD": 3, "BRL": 2,
+	"BYN": 2, "CAD": 2, "CHF": 2, "CLP": 0, "CNY": 2, "COP": 2, "CZK": 2,
+	"DKK": 2, "EGP": 2, "EUR": 2, "GBP": 2, "GEL": 2, "HKD": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "ISK": 0, "JOD": 3, "JPY": 0, "KGS": 2,
+	"KRW": 0, "KWD": 3, "KZT": 2, "MDL": 2, "MXN": 2, "MYR": 2, "NOK": 2,
+	"NZD": 2, "OMR": 3, "PHP": 2, "PLN": 2, "RON": 2, "RSD": 2, "RUB": 2,
+	"SAR": 2, "SEK": 2, "SGD": 2, "THB": 2, "TJS": 2, "TND": 3, "TRY": 2,
+	"TWD": 2, "UAH": 2, "USD": 2, "UZS": 2, "VND": 0, "ZAR": 2,
+}
+
+// Exponent returns the number of digits after the decimal point of the
+// currency.
+func Exponent(currency string) (int, error) {
+	exp, ok := exponents[currency]
+	if !ok {
+		return 0, ErrUnknownCurrency
+	}
+
+	return exp, nil
+}
+
+// Parse converts a decimal string like "12.30" to minor units of the
+// currency (1230 for USD). It never goes through floating point and
+// rejects amounts with more decimal places than the currency has.
+func Parse(amount string, currency string) (int64, error) {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return 0, err
+	}
+
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+
+	whole, frac, hasPoint := strings.Cut(amount, ".")
+	if whole == "" || (hasPoint && frac == "") || !digits(whole) || !digits(frac) {
+		return 0, ErrInvalidAmount
+	}
+
+	// trailing zeros add no precision, so "100.00" is a valid JPY amount
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > exp {
+		return 0, ErrTooPrecise
+	}
+
+	frac += strings.Repeat("0", exp-len(frac))
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+
+	if negative {
+		minor = -minor
+	}
+
+	return minor, nil
+}
+
+// Format converts minor units to a decimal string with exactly as many
+// decimal places as the currency has. Unknown currencies are formatted
+// with two decimal places.
+func Format(minor int64, currency string) string {
+	exp, err := Exponent(currency)
+	if err != nil {
+		exp = 2
+	}
+
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+	}
+
+	s := strconv.FormatUint(absUint(minor), 10)
+	if exp == 0 {
+		return sign + s
+	}
+
+	if len(s) <= exp {
+		s = strings.Repeat("0", exp-len(s)+1) + s
+	}
+
+	return sign + s[:len(s)-exp] + "." + s[len(s)-exp:]
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func absUint(n int64) uint64 {
+	if n == math.MinInt64 {
+		return uint64(math.MaxInt64) + 1
+	}
+	if n < 0 {
+		return uint64(-n)
+	}
+
+	return uint64(n)
+}
@@ -0,0 +1,72 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/lib/money"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		amount   string
+		currency string
+		want     int64
+		err      error
+	}{
+		{amount: "12.34", currency: "USD", want: 1234},
+		{amount: "12.3", currency: "USD", want: 1230},
+		{amount: "12", currency: "USD", want: 1200},
+		{amount: "0.01", currency: "EUR", want: 1},
+		{amount: "-5.50", currency: "EUR", want: -550},
+		{amount: "1500", currency: "JPY", want: 1500},
+		{amount: "1500.00", currency: "JPY", want: 1500},
+		{amount: "1.234", currency: "KWD", want: 1234},
+		{amount: "0.1", currency: "JPY", err: money.ErrTooPrecise},
+		{amount: "12.345", currency: "USD", err: money.ErrTooPrecise},
+		{amount: "12.", currency: "USD", err: money.ErrInvalidAmount},
+		{amount: ".5", currency: "USD", err: money.ErrInvalidAmount},
+		{amount: "1e3", currency: "USD", err: money.ErrInvalidAmount},
+		{amount: "", currency: "USD", err: money.ErrInvalidAmount},
+		{amount: "99999999999999999999", currency: "USD", err: money.ErrOverflow},
+		{amount: "1.00", currency: "XXX", err: money.ErrUnknownCurrency},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.amount+" "+tc.currency, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := money.Parse(tc.amount, tc.currency)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		minor    int64
+		currency string
+		want     string
+	}{
+		{minor: 1234, currency: "USD", want: "12.34"},
+		{minor: 5, currency: "USD", want: "0.05"},
+		{minor: 0, currency: "EUR", want: "0.00"},
+		{minor: -550, currency: "EUR", want: "-5.50"},
+		{minor: 1500, currency: "JPY", want: "1500"},
+		{minor: 1, currency: "BHD", want: "0.001"},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.want, money.Format(tc.minor, tc.currency))
+	}
+}
@@ -1,19 +1,87 @@
 package random
 
 import (
-	"math/rand"
-	"time"
+	"crypto/rand"
+	"errors"
+	"math/big"
 )
 
-var letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+// DefaultAlphabet is the alphabet of NewRandomString.
+const DefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
-func NewRandomString(length int) string {
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+var (
+	ErrInvalidLength   = errors.New("length must be positive")
+	ErrInvalidAlphabet = errors.New("alphabet must have at least 2 distinct characters")
+)
+
+// Generator makes random strings of a fixed length from an alphabet. The
+// characters are drawn uniformly from crypto/rand, so that the strings
+// can be neither predicted nor repeated by concurrent calls.
+type Generator struct {
+	length   int
+	alphabet []rune
+}
+
+// New returns a Generator of strings of length characters of alphabet,
+// which must not repeat any character.
+func New(length int, alphabet string) (*Generator, error) {
+	if length < 1 {
+		return nil, ErrInvalidLength
+	}
+
+	runes := []rune(alphabet)
+	seen := make(map[rune]bool, len(runes))
 
-	b := make([]rune, length)
+	for _, r := range runes {
+		if seen[r] {
+			return nil, ErrInvalidAlphabet
+		}
+
+		seen[r] = true
+	}
+
+	if len(runes) < 2 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	return &Generator{length: length, alphabet: runes}, nil
+}
+
+// Generate returns a new random string. It only fails if the random
+// source of the system does.
+func (g *Generator) Generate() (string, error) {
+	n := big.NewInt(int64(len(g.alphabet)))
+
+	b := make([]rune, g.length)
 	for i := range b {
-		b[i] = letterRunes[rnd.Intn(len(letterRunes))]
+		k, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+
+		b[i] = g.alphabet[k.Int64()]
+	}
+
+	return string(b), nil
+}
+
+// NewRandomString returns a random string of length characters of
+// DefaultAlphabet, empty if length is not positive. It panics if the
+// random source of the system fails.
+func NewRandomString(length int) string {
+	if length < 1 {
+		return ""
+	}
+
+	g, err := New(length, DefaultAlphabet)
+	if err != nil {
+		panic(err)
+	}
+
+	s, err := g.Generate()
+	if err != nil {
+		panic(err)
 	}
 
-	return string(b)
+	return s
 }
@@ -0,0 +1,48 @@
+package random
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	g, err := New(8, "ab")
+	require.NoError(t, err)
+
+	seen := map[string]bool{}
+
+	for i := 0; i < 50; i++ {
+		s, err := g.Generate()
+		require.NoError(t, err)
+		require.Len(t, s, 8)
+		require.Empty(t, strings.Trim(s, "ab"))
+
+		seen[s] = true
+	}
+
+	require.Greater(t, len(seen), 1)
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, err := New(0, DefaultAlphabet)
+	require.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = New(6, "a")
+	require.ErrorIs(t, err, ErrInvalidAlphabet)
+
+	_, err = New(6, "abca")
+	require.ErrorIs(t, err, ErrInvalidAlphabet)
+}
+
+func TestNewRandomString(t *testing.T) {
+	s := NewRandomString(10)
+
+	require.Len(t, s, 10)
+	require.Empty(t, strings.Trim(s, DefaultAlphabet))
+	require.NotEqual(t, s, NewRandomString(10))
+
+	require.Empty(t, NewRandomString(0))
+	require.Empty(t, NewRandomString(-1))
+}
@@ -0,0 +1,131 @@
+// Package shipping prices shipments by weight from per-zone rate tables
+// of weight brackets.
+package shipping
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go-api/internal/lib/money"
+)
+
+var (
+	ErrUnknownZone = errors.New("unknown shipping zone")
+	ErrTooHeavy    = errors.New("no shipping method takes this weight")
+)
+
+// Rate is the price, in minor units of the zone currency, of shipments
+// weighing up to MaxWeight, in the unit of goods weight.
+type Rate struct {
+	MaxWeight int64
+	Price     int64
+}
+
+// Method is a way of shipping to a zone, e.g. standard or express. Days
+// is the delivery estimate, zero if unknown.
+type Method struct {
+	Name  string
+	Days  int
+	Rates []Rate
+}
+
+type Zone struct {
+	Name     string
+	Currency string
+	Methods  []Method
+}
+
+// Option is the cost of shipping with a method.
+type Option struct {
+	Method   string
+	Days     int
+	Price    int64
+	Currency string
+}
+
+// Table holds the rate tables of all zones.
+type Table struct {
+	zones map[string]Zone
+}
+
+// New checks the zones and builds a table of them. Every zone needs an ISO
+// 4217 currency known to package money. Brackets may be given in any
+// order.
+func New(zones []Zone) (*Table, error) {
+	t := &Table{zones: make(map[string]Zone, len(zones))}
+
+	for _, z := range zones {
+		if z.Name == "" {
+			return nil, errors.New("shipping zone without a name")
+		}
+		if _, ok := t.zones[z.Name]; ok {
+			return nil, fmt.Errorf("duplicate shipping zone %q", z.Name)
+		}
+		if _, err := money.Exponent(z.Currency); err != nil {
+			return nil, fmt.Errorf("shipping zone %q: currency %q: %w", z.Name, z.Currency, err)
+		}
+		if len(z.Methods) == 0 {
+			return nil, fmt.Errorf("shipping zone %q has no methods", z.Name)
+		}
+
+		methods := make([]Method, 0, len(z.Methods))
+
+		for _, m := range z.Methods {
+			if m.Name == "" || len(m.Rates) == 0 {
+				return nil, fmt.Errorf("shipping zone %q: method %q without a name or rates", z.Name, m.Name)
+			}
+
+			rates := slices.Clone(m.Rates)
+			slices.SortFunc(rates, func(a, b Rate) int {
+				return cmp.Compare(a.MaxWeight, b.MaxWeight)
+			})
+
+			for i, r := range rates {
+				if r.MaxWeight <= 0 || r.Price < 0 {
+					return nil, fmt.Errorf("shipping zone %q: method %q: invalid rate", z.Name, m.Name)
+				}
+				if i > 0 && rates[i-1].MaxWeight == r.MaxWeight {
+					return nil, fmt.Errorf("shipping zone %q: method %q: duplicate bracket %d", z.Name, m.Name, r.MaxWeight)
+				}
+			}
+
+			m.Rates = rates
+			methods = append(methods, m)
+		}
+
+		z.Methods = methods
+		t.zones[z.Name] = z
+	}
+
+	return t, nil
+}
+
+// Quote returns the options of shipping weight to the zone, in the order
+// the methods are configured. Methods whose brackets end below weight are
+// left out.
+func (t *Table) Quote(zone string, weight int64) ([]Option, error) {
+	z, ok := t.zones[zone]
+	if !ok {
+		return nil, ErrUnknownZone
+	}
+
+	var options []Option
+
+	for _, m := range z.Methods {
+		for _, r := range m.Rates {
+			if weight <= r.MaxWeight {
+				options = append(options, Option{Method: m.Name, Days: m.Days, Price: r.Price, Currency: z.Currency})
+
+				break
+			}
+		}
+	}
+
+	if len(options) == 0 {
+		return nil, ErrTooHeavy
+	}
+
+	return options, nil
+}
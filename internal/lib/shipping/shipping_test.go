@@ -0,0 +1,86 @@
+package shipping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	table, err := New([]Zone{
+		{
+			Name:     "domestic",
+			Currency: "USD",
+			Methods: []Method{
+				{Name: "standard", Days: 5, Rates: []Rate{{MaxWeight: 2000, Price: 999}, {MaxWeight: 500, Price: 499}}},
+				{Name: "express", Days: 1, Rates: []Rate{{MaxWeight: 1000, Price: 1999}}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		zone    string
+		weight  int64
+		options []Option
+		err     error
+	}{
+		{
+			name:   "First bracket",
+			zone:   "domestic",
+			weight: 500,
+			options: []Option{
+				{Method: "standard", Days: 5, Price: 499, Currency: "USD"},
+				{Method: "express", Days: 1, Price: 1999, Currency: "USD"},
+			},
+		},
+		{
+			name:    "Only standard",
+			zone:    "domestic",
+			weight:  1500,
+			options: []Option{{Method: "standard", Days: 5, Price: 999, Currency: "USD"}},
+		},
+		{
+			name:   "Too heavy",
+			zone:   "domestic",
+			weight: 2001,
+			err:    ErrTooHeavy,
+		},
+		{
+			name: "Unknown zone",
+			zone: "mars",
+			err:  ErrUnknownZone,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			options, err := table.Quote(tc.zone, tc.weight)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.options, options)
+		})
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	methods := []Method{{Name: "m", Rates: []Rate{{MaxWeight: 1}}}}
+
+	for _, zones := range [][]Zone{
+		{{Name: "", Currency: "USD"}},
+		{{Name: "a", Currency: "USD", Methods: methods}, {Name: "a", Currency: "USD", Methods: methods}},
+		{{Name: "a", Methods: methods}},
+		{{Name: "a", Currency: "XYZ", Methods: methods}},
+		{{Name: "a", Currency: "USD"}},
+		{{Name: "a", Currency: "USD", Methods: []Method{{Name: "m"}}}},
+		{{Name: "a", Currency: "USD", Methods: []Method{{Name: "m", Rates: []Rate{{MaxWeight: 0, Price: 1}}}}}},
+		{{Name: "a", Currency: "USD", Methods: []Method{{Name: "m", Rates: []Rate{{MaxWeight: 5}, {MaxWeight: 5}}}}}},
+	} {
+		_, err := New(zones)
+		require.Error(t, err)
+	}
+}
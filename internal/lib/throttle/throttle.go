@@ -0,0 +1,109 @@
+// Package throttle limits failed attempts, such as password guesses, per
+// key.
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter blocks a key for the rest of a window once it failed
+// maxFailures times in it. The window starts at the first failure.
+type Limiter struct {
+	mu          sync.Mutex
+	maxFailures int
+	window      time.Duration
+	keys        map[string]*failures
+	lastSweep   time.Time
+}
+
+type failures struct {
+	count   int
+	resetAt time.Time
+}
+
+func New(maxFailures int, window time.Duration) *Limiter {
+	return &Limiter{
+		maxFailures: maxFailures,
+		window:      window,
+		keys:        make(map[string]*failures),
+	}
+}
+
+// Wait returns how long key is blocked for at now, zero if it is not.
+func (l *Limiter) Wait(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.wait(key, now)
+}
+
+// Fail records a failed attempt for key at now.
+func (l *Limiter) Fail(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.fail(key, now)
+}
+
+// Reserve records an attempt for key at now as failed in advance, unless
+// key is blocked: then it returns how long for and records nothing. Unlike
+// Wait followed by Fail, concurrent attempts can't all get past the check
+// before the first of them fails. An attempt that succeeds calls Reset.
+func (l *Limiter) Reserve(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if wait := l.wait(key, now); wait > 0 {
+		return wait
+	}
+
+	l.fail(key, now)
+
+	return 0
+}
+
+func (l *Limiter) wait(key string, now time.Time) time.Duration {
+	f, ok := l.keys[key]
+	if !ok || !now.Before(f.resetAt) || f.count < l.maxFailures {
+		return 0
+	}
+
+	return f.resetAt.Sub(now)
+}
+
+func (l *Limiter) fail(key string, now time.Time) {
+	l.sweep(now)
+
+	f, ok := l.keys[key]
+	if !ok || !now.Before(f.resetAt) {
+		f = &failures{resetAt: now.Add(l.window)}
+		l.keys[key] = f
+	}
+
+	f.count++
+}
+
+// Reset forgets the failures of key, after a successful attempt.
+func (l *Limiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.keys, key)
+}
+
+// sweep drops the keys whose window has passed, at most once a window so
+// that the keys do not pile up.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+
+	for key, f := range l.keys {
+		if !now.Before(f.resetAt) {
+			delete(l.keys, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,75 @@
+package throttle
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := New(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		require.Zero(t, l.Wait("abc", now))
+
+		l.Fail("abc", now.Add(time.Duration(i)*time.Second))
+	}
+
+	require.Equal(t, 50*time.Second, l.Wait("abc", now.Add(10*time.Second)))
+	require.Zero(t, l.Wait("xyz", now.Add(10*time.Second)), "keys are limited separately")
+	require.Zero(t, l.Wait("abc", now.Add(time.Minute)), "the window has passed")
+
+	l.Fail("abc", now.Add(time.Minute))
+	require.Zero(t, l.Wait("abc", now.Add(time.Minute)), "a new window starts")
+
+	l.Fail("abc", now.Add(time.Minute))
+	l.Fail("abc", now.Add(time.Minute))
+	require.NotZero(t, l.Wait("abc", now.Add(time.Minute)))
+
+	l.Reset("abc")
+	require.Zero(t, l.Wait("abc", now.Add(time.Minute)))
+}
+
+func TestLimiterReserve(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := New(3, time.Minute)
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int32
+	)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if l.Reserve("abc", now) == 0 {
+				allowed.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int32(3), allowed.Load())
+	require.Equal(t, time.Minute, l.Reserve("abc", now))
+
+	l.Reset("abc")
+	require.Zero(t, l.Reserve("abc", now))
+}
+
+func TestLimiterSweep(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l := New(3, time.Minute)
+
+	l.Fail("abc", now)
+	l.Fail("xyz", now.Add(2*time.Minute))
+
+	require.Len(t, l.keys, 1)
+}
@@ -0,0 +1,73 @@
+package thumbnail
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit returns the size of an image of w x h scaled down to fit into a
+// square with the given side, keeping its aspect ratio. Images that
+// already fit are not scaled up.
+func Fit(w, h, side int) (int, int) {
+	if w <= side && h <= side {
+		return w, h
+	}
+
+	if w >= h {
+		return side, max(1, h*side/w)
+	}
+
+	return max(1, w*side/h), side
+}
+
+// Resize scales img down to fit into a square with the given side by
+// averaging the source pixels covered by every destination pixel.
+func Resize(img image.Image, side int) *image.RGBA {
+	b := img.Bounds()
+
+	// work on premultiplied RGBA so that transparent pixels do not bleed
+	// their colour into the average
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := Fit(sw, sh, side)
+
+	if dw == sw && dh == sh {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, bl, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+
+					r += uint64(p[0])
+					g += uint64(p[1])
+					bl += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(bl / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
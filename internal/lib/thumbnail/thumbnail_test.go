@@ -0,0 +1,53 @@
+package thumbnail_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/lib/thumbnail"
+)
+
+func TestFit(t *testing.T) {
+	cases := []struct {
+		name         string
+		w, h, side   int
+		wantW, wantH int
+	}{
+		{name: "Landscape", w: 1000, h: 500, side: 100, wantW: 100, wantH: 50},
+		{name: "Portrait", w: 300, h: 1200, side: 400, wantW: 100, wantH: 400},
+		{name: "Already fits", w: 64, h: 32, side: 128, wantW: 64, wantH: 32},
+		{name: "Thin", w: 5000, h: 2, side: 100, wantW: 100, wantH: 1},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w, h := thumbnail.Fit(tc.w, tc.h, tc.side)
+
+			require.Equal(t, tc.wantW, w)
+			require.Equal(t, tc.wantH, h)
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	// left half red, right half transparent
+	src := image.NewNRGBA(image.Rect(10, 10, 50, 30))
+	for y := 10; y < 30; y++ {
+		for x := 10; x < 30; x++ {
+			src.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	dst := thumbnail.Resize(src, 4)
+
+	require.Equal(t, image.Rect(0, 0, 4, 2), dst.Bounds())
+	require.Equal(t, color.RGBA{R: 255, A: 255}, dst.RGBAAt(0, 0))
+	require.Equal(t, color.RGBA{}, dst.RGBAAt(3, 1))
+}
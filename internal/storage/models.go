@@ -0,0 +1,395 @@
+package storage
+
+import (
+	"slices"
+	"time"
+
+	"go-api/internal/lib/discount"
+)
+
+type Goods struct {
+	ID          int64
+	Title       string
+	Price       int64 // minor units of Currency
+	Currency    string
+	Description string
+	ImgUrl      string
+	ImageID     int64 // uploaded image, 0 if there is none
+	Weight      int32
+	Version     int64
+	DeletedAt   time.Time // only set for goods listed from the trash
+}
+
+// URL is a short link. DeletedAt is zero unless the link is in the trash.
+// A link expires at ExpiresAt, or once followed MaxClicks times; zero
+// ExpiresAt and MaxClicks never expire. ClickCount only counts the clicks
+// of links with MaxClicks. Links with a PasswordHash, a bcrypt hash, ask
+// for the password before redirecting.
+type URL struct {
+	ID           int64
+	Alias        string
+	URL          string
+	ExpiresAt    time.Time
+	MaxClicks    int64
+	ClickCount   int64
+	PasswordHash string
+	Version      int64
+	CreatedAt    time.Time
+	DeletedAt    time.Time
+}
+
+// Expired reports whether the link can no longer be followed at now.
+func (u URL) Expired(now time.Time) bool {
+	return (!u.ExpiresAt.IsZero() && !now.Before(u.ExpiresAt)) || (u.MaxClicks > 0 && u.ClickCount >= u.MaxClicks)
+}
+
+// URLListOptions describes a page of the url listing. Query matches the
+// alias or the target of the url, as a prefix of either with Prefix set.
+// Zero created-at bounds are not applied; CreatedTo is exclusive.
+type URLListOptions struct {
+	Query       string
+	Prefix      bool
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	SortBy      string // created, alias, url, clicks
+	Desc        bool
+	Limit       int
+	Offset      int
+}
+
+// URLSummary is an url of the listing with the number of its redirects.
+type URLSummary struct {
+	URL
+	Clicks int64
+}
+
+// URLRevision is a previous target of a short link: URL was its target
+// at Version, until Actor replaced it at ReplacedAt.
+type URLRevision struct {
+	ID         int64
+	Version    int64
+	URL        string
+	Actor      string
+	ReplacedAt time.Time
+}
+
+// Click is a redirect through a short link. IPHash identifies the visitor
+// without keeping their address.
+type Click struct {
+	Alias     string
+	At        time.Time
+	Referrer  string
+	UserAgent string
+	IPHash    string
+}
+
+// ClickStats counts the clicks of a short link, in total and in buckets
+// of time. Unique counts distinct visitors.
+type ClickStats struct {
+	Total   int64
+	Unique  int64
+	Buckets []ClickBucket
+}
+
+type ClickBucket struct {
+	Start  time.Time
+	Clicks int64
+	Unique int64
+}
+
+// Actions recorded in the goods history.
+const (
+	GoodsCreated  = "create"
+	GoodsUpdated  = "update"
+	GoodsDeleted  = "delete"
+	GoodsRestored = "restore"
+)
+
+// GoodsRevision is an entry of the goods history. Old is nil for created
+// goods and New is nil for deleted ones; Version is that of New, or of Old
+// for deletions.
+type GoodsRevision struct {
+	ID        int64
+	GoodsID   int64
+	Action    string
+	Actor     string
+	Version   int64
+	Old       *Goods
+	New       *Goods
+	CreatedAt time.Time
+}
+
+// GoodsListOptions describes a page of the goods listing.
+// AfterID enables cursor pagination and takes precedence over Offset.
+// CategoryID limits the listing to a category and all its descendants.
+type GoodsListOptions struct {
+	Limit      int
+	Offset     int
+	AfterID    int64
+	SortBy     string // id, price, title
+	Desc       bool
+	CategoryID int64
+}
+
+// Price is an amount in minor units of an ISO 4217 currency.
+type Price struct {
+	Currency string
+	Amount   int64
+}
+
+// GoodsSearchOptions describes a full-text search over goods.
+// Nil range bounds are not applied. Currency limits the search to goods
+// priced in it and is required for price bounds to be meaningful.
+type GoodsSearchOptions struct {
+	Query     string
+	Currency  string
+	MinPrice  *int64
+	MaxPrice  *int64
+	MinWeight *int32
+	MaxWeight *int32
+	Limit     int
+	Offset    int
+}
+
+type GoodsSearchResult struct {
+	Goods
+	Highlight string // HTML-escaped title with matches in <mark> tags
+	Snippet   string // HTML-escaped description fragment with matches in <mark> tags
+	Rank      float64
+}
+
+type Category struct {
+	ID       int64
+	Name     string
+	ParentID int64 // 0 for root categories
+}
+
+// Stock movement kinds.
+const (
+	StockReceive = "receive" // goods arrived, stock grows
+	StockAdjust  = "adjust"  // manual correction by a signed quantity
+	StockReserve = "reserve" // part of the available stock is held
+	StockRelease = "release" // a reservation is returned to available stock
+	StockCommit  = "commit"  // reserved goods are shipped and leave stock
+)
+
+type Stock struct {
+	GoodsID           int64
+	OnHand            int64
+	Reserved          int64
+	LowStockThreshold int64
+}
+
+// Available is the stock that can still be reserved.
+func (s Stock) Available() int64 {
+	return s.OnHand - s.Reserved
+}
+
+type StockMovement struct {
+	ID        int64
+	GoodsID   int64
+	Kind      string
+	Quantity  int64
+	OnHand    int64 // after the movement
+	Reserved  int64 // after the movement
+	Reference string
+	CreatedAt time.Time
+}
+
+type LowStockItem struct {
+	Goods Goods
+	Stock Stock
+}
+
+// Image is an uploaded image. Its original is stored as a blob under
+// Key and a thumbnail of every size in Thumbnails under Key/size.
+type Image struct {
+	ID          int64
+	Key         string
+	ContentType string
+	Size        int64
+	Width       int
+	Height      int
+	Thumbnails  []int
+	CreatedAt   time.Time
+}
+
+// Variant is a purchasable version of goods, such as a T-shirt of one size
+// and colour. Nil Price and Weight fall back to those of the goods; Price
+// is in the goods currency.
+type Variant struct {
+	ID      int64
+	GoodsID int64
+	SKU     string
+	Options map[string]string
+	Price   *int64
+	Weight  *int32
+	Stock   int64
+}
+
+// EffectivePrice is the price of the variant in minor units of the goods
+// currency.
+func (v Variant) EffectivePrice(goods Goods) int64 {
+	if v.Price != nil {
+		return *v.Price
+	}
+
+	return goods.Price
+}
+
+func (v Variant) EffectiveWeight(goods Goods) int32 {
+	if v.Weight != nil {
+		return *v.Weight
+	}
+
+	return goods.Weight
+}
+
+type Cart struct {
+	ID        string
+	Currency  string
+	Items     []LineItem
+	CreatedAt time.Time
+}
+
+// Total is the price in minor units of the cart currency and the weight
+// of all items.
+func (c Cart) Total() (price int64, weight int64) {
+	return lineTotals(c.Items)
+}
+
+// LineItem is a cart or order line. Price and Weight are per unit; in a
+// cart they are resolved from the catalog on every read, in an order they
+// are copied at placement.
+type LineItem struct {
+	ID       int64
+	GoodsID  int64
+	SKU      string
+	Title    string
+	Price    int64
+	Weight   int32
+	Quantity int64
+}
+
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"
+	OrderShipped   = "shipped"
+	OrderCancelled = "cancelled"
+)
+
+// orderTransitions lists the statuses each order status may change to.
+// Shipped and cancelled orders are final.
+var orderTransitions = map[string][]string{
+	OrderPending: {OrderPaid, OrderCancelled},
+	OrderPaid:    {OrderShipped, OrderCancelled},
+}
+
+// CanChangeOrderStatus reports whether an order in status from may move to
+// status to.
+func CanChangeOrderStatus(from, to string) bool {
+	return slices.Contains(orderTransitions[from], to)
+}
+
+type Order struct {
+	ID            int64
+	Status        string
+	Currency      string
+	Total         int64 // after Discount
+	Discount      int64
+	PromotionCode string
+	Weight        int64
+	Items         []LineItem
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func lineTotals(items []LineItem) (price int64, weight int64) {
+	for _, item := range items {
+		price += item.Price * item.Quantity
+		weight += int64(item.Weight) * item.Quantity
+	}
+
+	return price, weight
+}
+
+const (
+	PromotionPercent = "percent"
+	PromotionFixed   = "fixed"
+)
+
+// Promotion is a discount code. Value is a percentage for PromotionPercent
+// and minor units of Currency for PromotionFixed, which only applies to
+// amounts in that currency. Zero StartsAt, EndsAt and UsageLimit are
+// unbounded. Without CategoryIDs and GoodsIDs it applies to all goods.
+type Promotion struct {
+	ID          int64
+	Code        string
+	Kind        string
+	Value       int64
+	Currency    string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	UsageLimit  int64
+	Used        int64
+	CategoryIDs []int64
+	GoodsIDs    []int64
+	CreatedAt   time.Time
+}
+
+// Check reports why the promotion cannot be applied at now to amounts in
+// currency, if it cannot.
+func (p Promotion) Check(now time.Time, currency string) error {
+	if (!p.StartsAt.IsZero() && now.Before(p.StartsAt)) || (!p.EndsAt.IsZero() && !now.Before(p.EndsAt)) {
+		return ErrPromotionInactive
+	}
+	if p.UsageLimit > 0 && p.Used >= p.UsageLimit {
+		return ErrPromotionUsedUp
+	}
+	if p.Kind == PromotionFixed && p.Currency != currency {
+		return ErrPromotionCurrency
+	}
+
+	return nil
+}
+
+// Scoped reports whether the promotion is limited to some categories or
+// goods.
+func (p Promotion) Scoped() bool {
+	return len(p.CategoryIDs) > 0 || len(p.GoodsIDs) > 0
+}
+
+// Discounts returns the discount on each amount. Amounts out of the scope
+// of the promotion should be passed as zero.
+func (p Promotion) Discounts(amounts []int64) []int64 {
+	if p.Kind == PromotionFixed {
+		return discount.Fixed(amounts, p.Value)
+	}
+
+	return discount.Percent(amounts, p.Value)
+}
+
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// Review is a rating of goods from 1 to 5. Reviews start pending and are
+// only shown and counted in the Rating of the goods once approved.
+type Review struct {
+	ID        int64
+	GoodsID   int64
+	Author    string
+	Rating    int
+	Text      string
+	Status    string
+	CreatedAt time.Time
+}
+
+// Rating aggregates the approved reviews of goods. Average is zero
+// without reviews.
+type Rating struct {
+	Average float64
+	Count   int64
+}
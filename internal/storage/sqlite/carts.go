@@ -0,0 +1,225 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go-api/internal/storage"
+)
+
+type querier interface {
+	queryRower
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func (s *Storage) SaveCart(id string, currency string) error {
+	const op = "storage.sqlite.SaveCart"
+
+	stmt, err := s.db.Prepare("INSERT INTO carts(id, currency) VALUES (?, ?)")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := stmt.Exec(id, currency); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCart(id string) (storage.Cart, error) {
+	const op = "storage.sqlite.GetCart"
+
+	cart, err := getCart(s.db, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Cart{}, storage.ErrCartNotFound
+	}
+	if isStaleCartItemErr(err) {
+		return storage.Cart{}, err
+	}
+	if err != nil {
+		return storage.Cart{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cart, nil
+}
+
+// AddCartItem adds the quantity of the item to the cart, merging it with
+// a line of the same goods and variant. It returns the id of the line.
+func (s *Storage) AddCartItem(cartID string, item storage.LineItem) (int64, error) {
+	const op = "storage.sqlite.AddCartItem"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var currency string
+
+	err = tx.QueryRow("SELECT currency FROM carts WHERE id = ?", cartID).Scan(&currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, storage.ErrCartNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+
+	err = tx.QueryRow(`
+		INSERT INTO cart_items(cart_id, goods_id, sku, quantity) VALUES (?, ?, ?, ?)
+		ON CONFLICT (cart_id, goods_id, sku) DO UPDATE SET quantity = quantity + excluded.quantity
+		RETURNING id`,
+		cartID, item.GoodsID, item.SKU, item.Quantity).Scan(&id)
+	if isForeignKeyErr(err) {
+		return 0, storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the line must be priced in the cart currency before it is accepted
+	if _, err := cartItems(tx, cartID, id); err != nil {
+		if isStaleCartItemErr(err) {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) RemoveCartItem(cartID string, itemID int64) error {
+	const op = "storage.sqlite.RemoveCartItem"
+
+	stmt, err := s.db.Prepare("DELETE FROM cart_items WHERE cart_id = ? AND id = ?")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(cartID, itemID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrCartItemNotFound
+	}
+
+	return nil
+}
+
+func getCart(q querier, id string) (storage.Cart, error) {
+	cart := storage.Cart{ID: id}
+
+	err := q.QueryRow("SELECT currency, created_at FROM carts WHERE id = ?", id).
+		Scan(&cart.Currency, &cart.CreatedAt)
+	if err != nil {
+		return storage.Cart{}, err
+	}
+
+	cart.Items, err = cartItems(q, id, 0)
+	if err != nil {
+		return storage.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+// cartItems resolves the unit prices and weights of the lines of the cart,
+// or of a single line when itemID is not zero. A line whose variant was
+// removed or that has no price in the cart currency fails with
+// storage.ErrVariantNotFound or storage.ErrPriceNotFound naming the line,
+// and so does a line of goods in the trash with storage.ErrGoodsNotFound,
+// so that it can be removed from the cart.
+func cartItems(q querier, cartID string, itemID int64) ([]storage.LineItem, error) {
+	rows, err := q.Query(`
+		SELECT ci.id, ci.goods_id, ci.sku, ci.quantity, g.title, g.currency, g.price, g.weight,
+			c.currency, gp.amount, v.id IS NOT NULL, v.price, v.weight, g.deleted_at IS NOT NULL
+		FROM cart_items ci
+		JOIN carts c ON c.id = ci.cart_id
+		JOIN goods g ON g.id = ci.goods_id
+		LEFT JOIN goods_prices gp ON gp.goods_id = g.id AND gp.currency = c.currency
+		LEFT JOIN goods_variants v ON v.goods_id = g.id AND v.sku = ci.sku
+		WHERE ci.cart_id = ?1 AND (?2 = 0 OR ci.id = ?2)
+		ORDER BY ci.id`, cartID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []storage.LineItem{}
+
+	for rows.Next() {
+		var (
+			item          storage.LineItem
+			goods         storage.Goods
+			cartCurrency  string
+			listPrice     sql.NullInt64
+			hasVariant    bool
+			variantPrice  sql.NullInt64
+			variantWeight sql.NullInt32
+			deleted       bool
+		)
+
+		err := rows.Scan(&item.ID, &item.GoodsID, &item.SKU, &item.Quantity, &item.Title,
+			&goods.Currency, &goods.Price, &goods.Weight,
+			&cartCurrency, &listPrice, &hasVariant, &variantPrice, &variantWeight, &deleted)
+		if err != nil {
+			return nil, err
+		}
+
+		if deleted {
+			return nil, fmt.Errorf("%w: cart item %d", storage.ErrGoodsNotFound, item.ID)
+		}
+
+		if item.SKU != "" && !hasVariant {
+			return nil, fmt.Errorf("%w: cart item %d", storage.ErrVariantNotFound, item.ID)
+		}
+
+		variant := storage.Variant{}
+		if variantPrice.Valid {
+			variant.Price = &variantPrice.Int64
+		}
+		if variantWeight.Valid {
+			variant.Weight = &variantWeight.Int32
+		}
+
+		// variant price overrides are in the goods currency and have no
+		// counterpart in the price list
+		switch {
+		case cartCurrency == goods.Currency:
+			item.Price = variant.EffectivePrice(goods)
+		case listPrice.Valid && variant.Price == nil:
+			item.Price = listPrice.Int64
+		default:
+			return nil, fmt.Errorf("%w: cart item %d in %s", storage.ErrPriceNotFound, item.ID, cartCurrency)
+		}
+
+		item.Weight = variant.EffectiveWeight(goods)
+
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// isStaleCartItemErr reports whether err is a line error of cartItems.
+func isStaleCartItemErr(err error) bool {
+	return errors.Is(err, storage.ErrVariantNotFound) || errors.Is(err, storage.ErrPriceNotFound) ||
+		errors.Is(err, storage.ErrGoodsNotFound)
+}
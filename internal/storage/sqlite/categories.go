@@ -0,0 +1,260 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+
+	"go-api/internal/storage"
+)
+
+// categorySubtree selects the id bound to its single parameter together
+// with the ids of all its descendants.
+const categorySubtree = `
+	WITH RECURSIVE subtree(id) AS (
+		SELECT ?
+		UNION
+		SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+	)
+	SELECT id FROM subtree`
+
+func (s *Storage) SaveCategory(name string, parentID int64) (int64, error) {
+	const op = "storage.sqlite.SaveCategory"
+
+	stmt, err := s.db.Prepare("INSERT INTO categories(name, parent_id) VALUES (?, ?)")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(name, nullID(parentID))
+	if err != nil {
+		if isForeignKeyErr(err) {
+			return 0, fmt.Errorf("%s: parent: %w", op, storage.ErrCategoryNotFound)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetCategory(id int64) (storage.Category, error) {
+	const op = "storage.sqlite.GetCategory"
+
+	stmt, err := s.db.Prepare("SELECT id, name, COALESCE(parent_id, 0) FROM categories WHERE id = ?")
+	if err != nil {
+		return storage.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var category storage.Category
+
+	err = stmt.QueryRow(id).Scan(&category.ID, &category.Name, &category.ParentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Category{}, storage.ErrCategoryNotFound
+	}
+	if err != nil {
+		return storage.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return category, nil
+}
+
+// ListCategories returns all categories ordered by name; the tree is
+// small enough to be assembled by the caller.
+func (s *Storage) ListCategories() ([]storage.Category, error) {
+	const op = "storage.sqlite.ListCategories"
+
+	rows, err := s.db.Query("SELECT id, name, COALESCE(parent_id, 0) FROM categories ORDER BY name, id")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	return scanCategories(rows, op)
+}
+
+func (s *Storage) UpdateCategory(category storage.Category) error {
+	const op = "storage.sqlite.UpdateCategory"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if category.ParentID != 0 {
+		// the new parent must not be the category itself or one of its descendants
+		var cycle bool
+
+		err := tx.QueryRow("SELECT ? IN ("+categorySubtree+")", category.ParentID, category.ID).Scan(&cycle)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if cycle {
+			return storage.ErrCategoryCycle
+		}
+	}
+
+	res, err := tx.Exec("UPDATE categories SET name = ?, parent_id = ? WHERE id = ?",
+		category.Name, nullID(category.ParentID), category.ID)
+	if err != nil {
+		if isForeignKeyErr(err) {
+			return fmt.Errorf("%s: parent: %w", op, storage.ErrCategoryNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrCategoryNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteCategory removes a category without subcategories; its goods
+// assignments are removed with it.
+func (s *Storage) DeleteCategory(id int64) error {
+	const op = "storage.sqlite.DeleteCategory"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var hasChildren bool
+
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE parent_id = ?)", id).Scan(&hasChildren); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if hasChildren {
+		return storage.ErrCategoryHasChildren
+	}
+
+	res, err := tx.Exec("DELETE FROM categories WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrCategoryNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetGoodsCategories replaces the set of categories the goods belongs to.
+func (s *Storage) SetGoodsCategories(goodsID int64, categoryIDs []int64) error {
+	const op = "storage.sqlite.SetGoodsCategories"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM goods WHERE id = ? AND deleted_at IS NULL)", goodsID).Scan(&exists); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return storage.ErrGoodsNotFound
+	}
+
+	if _, err := tx.Exec("DELETE FROM goods_categories WHERE goods_id = ?", goodsID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err := tx.Prepare("INSERT OR IGNORE INTO goods_categories(goods_id, category_id) VALUES (?, ?)")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, categoryID := range categoryIDs {
+		if _, err := stmt.Exec(goodsID, categoryID); err != nil {
+			if isForeignKeyErr(err) {
+				return fmt.Errorf("%s: %d: %w", op, categoryID, storage.ErrCategoryNotFound)
+			}
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetGoodsCategories(goodsID int64) ([]storage.Category, error) {
+	const op = "storage.sqlite.GetGoodsCategories"
+
+	rows, err := s.db.Query(`
+		SELECT c.id, c.name, COALESCE(c.parent_id, 0)
+		FROM categories c
+		JOIN goods_categories gc ON gc.category_id = c.id
+		WHERE gc.goods_id = ?
+		ORDER BY c.name, c.id`, goodsID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	return scanCategories(rows, op)
+}
+
+func scanCategories(rows *sql.Rows, op string) ([]storage.Category, error) {
+	categories := []storage.Category{}
+
+	for rows.Next() {
+		var c storage.Category
+
+		if err := rows.Scan(&c.ID, &c.Name, &c.ParentID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		categories = append(categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categories, nil
+}
+
+func nullID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+func isForeignKeyErr(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/storage"
+)
+
+func TestCategoriesConcurrently(t *testing.T) {
+	const n = 16
+
+	s := newTestStorage(t)
+	goods := saveTestGoods(t, s, n)
+
+	root, err := s.SaveCategory("root", 0)
+	require.NoError(t, err)
+
+	errs := concurrently(n, func(i int) error {
+		id, err := s.SaveCategory(fmt.Sprintf("category %d", i), 0)
+		if err != nil {
+			return err
+		}
+
+		if err := s.UpdateCategory(storage.Category{ID: id, Name: fmt.Sprintf("renamed %d", i), ParentID: root}); err != nil {
+			return err
+		}
+
+		if err := s.SetGoodsCategories(goods[i].ID, []int64{id, root}); err != nil {
+			return err
+		}
+
+		return s.DeleteCategory(id)
+	})
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
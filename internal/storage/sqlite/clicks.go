@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-api/internal/storage"
+)
+
+// SaveClicks records a batch of clicks in a single transaction.
+func (s *Storage) SaveClicks(clicks []storage.Click) error {
+	const op = "storage.sqlite.SaveClicks"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO clicks(alias, clicked_at, referrer, user_agent, ip_hash)
+		VALUES (?, datetime(?, 'unixepoch'), ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, c := range clicks {
+		if _, err := stmt.Exec(c.Alias, c.At.Unix(), c.Referrer, c.UserAgent, c.IPHash); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetClickStats counts all clicks of the url and those from from until
+// to in buckets of bucket, aligned to UTC. Buckets without clicks are
+// left out.
+func (s *Storage) GetClickStats(alias string, from time.Time, to time.Time, bucket time.Duration) (storage.ClickStats, error) {
+	const op = "storage.sqlite.GetClickStats"
+
+	var id int64
+
+	err := s.db.QueryRow("SELECT id FROM url WHERE alias = ? AND deleted_at IS NULL", alias).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ClickStats{}, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return storage.ClickStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var stats storage.ClickStats
+
+	err = s.db.QueryRow("SELECT COUNT(*), COUNT(DISTINCT ip_hash) FROM clicks WHERE alias = ?", alias).
+		Scan(&stats.Total, &stats.Unique)
+	if err != nil {
+		return storage.ClickStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	size := int64(bucket / time.Second)
+
+	rows, err := s.db.Query(`
+		SELECT CAST(strftime('%s', clicked_at) AS INTEGER) / ?1 * ?1 AS start, COUNT(*), COUNT(DISTINCT ip_hash)
+		FROM clicks
+		WHERE alias = ?2 AND clicked_at >= datetime(?3, 'unixepoch') AND clicked_at < datetime(?4, 'unixepoch')
+		GROUP BY start
+		ORDER BY start`, size, alias, from.Unix(), to.Unix())
+	if err != nil {
+		return storage.ClickStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	stats.Buckets = []storage.ClickBucket{}
+
+	for rows.Next() {
+		var (
+			b     storage.ClickBucket
+			start int64
+		)
+
+		if err := rows.Scan(&start, &b.Clicks, &b.Unique); err != nil {
+			return storage.ClickStats{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		b.Start = time.Unix(start, 0).UTC()
+
+		stats.Buckets = append(stats.Buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return storage.ClickStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
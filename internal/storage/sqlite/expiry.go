@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+)
+
+// urlExpired matches the urls storage.URL.Expired reports as expired at
+// the Unix time of parameter ?1.
+const urlExpired = `((expires_at IS NOT NULL AND expires_at <= datetime(?1, 'unixepoch'))
+	OR (max_clicks > 0 AND click_count >= max_clicks))`
+
+// ArchiveExpiredURLs moves the urls expired at now to the trash and
+// returns how many there were.
+func (s *Storage) ArchiveExpiredURLs(now time.Time) (int64, error) {
+	const op = "storage.sqlite.ArchiveExpiredURLs"
+
+	res, err := s.db.Exec(`
+		UPDATE url SET deleted_at = CURRENT_TIMESTAMP
+		WHERE deleted_at IS NULL AND `+urlExpired, now.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	archived, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	return archived, nil
+}
+
+// PurgeExpiredURLs permanently deletes the urls expired at now, with
+// their clicks, and returns how many there were. Expired urls in the
+// trash are purged too.
+func (s *Storage) PurgeExpiredURLs(now time.Time) (int64, error) {
+	const op = "storage.sqlite.PurgeExpiredURLs"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec("DELETE FROM clicks WHERE alias IN (SELECT alias FROM url WHERE "+urlExpired+")", now.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec("DELETE FROM url WHERE "+urlExpired, now.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
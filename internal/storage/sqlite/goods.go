@@ -0,0 +1,450 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-api/internal/storage"
+)
+
+// goodsColumns are read by every goods query, always aliasing the goods
+// table as g, and scanned with goodsFields.
+const goodsColumns = "g.id, g.title, g.price, g.currency, COALESCE(g.description, ''), g.imgUrl, COALESCE(g.image_id, 0), g.weight, g.version"
+
+func goodsFields(g *storage.Goods) []any {
+	return []any{&g.ID, &g.Title, &g.Price, &g.Currency, &g.Description, &g.ImgUrl, &g.ImageID, &g.Weight, &g.Version}
+}
+
+// SaveGoods inserts the goods and records it in the goods history as
+// created by actor.
+func (s *Storage) SaveGoods(goods storage.Goods, actor string) (int64, error) {
+	const op = "storage.sqlite.SaveGoods"
+
+	ids, err := s.saveGoods([]storage.Goods{goods}, actor)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids[0], nil
+}
+
+// SaveGoodsBatch inserts all goods in a single transaction and returns
+// their ids in order. Either all goods are saved or none.
+func (s *Storage) SaveGoodsBatch(goods []storage.Goods, actor string) ([]int64, error) {
+	const op = "storage.sqlite.SaveGoodsBatch"
+
+	ids, err := s.saveGoods(goods, actor)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+func (s *Storage) saveGoods(goods []storage.Goods, actor string) ([]int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare("INSERT INTO goods(title, price, currency, description, imgUrl, image_id, weight) VALUES (?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	ids := make([]int64, 0, len(goods))
+
+	for _, g := range goods {
+		res, err := stmt.Exec(g.Title, g.Price, g.Currency, g.Description, g.ImgUrl, nullID(g.ImageID), g.Weight)
+		if err != nil {
+			if isForeignKeyErr(err) {
+				return nil, storage.ErrImageNotFound
+			}
+
+			return nil, err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		g.ID, g.Version = id, 1
+
+		if err := recordGoodsHistory(tx, storage.GoodsCreated, actor, nil, &g); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (s *Storage) GetGoods(id int64) (storage.Goods, error) {
+	const op = "storage.sqlite.GetGoods"
+
+	goods, err := getGoods(s.db, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Goods{}, storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return goods, nil
+}
+
+func (s *Storage) ListGoods(opts storage.GoodsListOptions) ([]storage.Goods, error) {
+	const op = "storage.sqlite.ListGoods"
+
+	column := goodsSortColumn(opts.SortBy)
+
+	cmp, order := ">", "ASC"
+	if opts.Desc {
+		cmp, order = "<", "DESC"
+	}
+
+	var (
+		where = []string{"deleted_at IS NULL"}
+		args  []any
+	)
+
+	if opts.CategoryID > 0 {
+		where = append(where, "id IN (SELECT goods_id FROM goods_categories WHERE category_id IN ("+categorySubtree+"))")
+		args = append(args, opts.CategoryID)
+	}
+
+	if opts.AfterID > 0 {
+		if column == "id" {
+			where = append(where, "id "+cmp+" ?")
+			args = append(args, opts.AfterID)
+		} else {
+			// keyset on (column, id) so that equal sort values are not skipped
+			where = append(where, fmt.Sprintf("(%s, id) %s ((SELECT %s FROM goods WHERE id = ?), ?)", column, cmp, column))
+			args = append(args, opts.AfterID, opts.AfterID)
+		}
+	}
+
+	query := "SELECT " + goodsColumns + " FROM goods g WHERE " + strings.Join(where, " AND ")
+
+	query += " ORDER BY "
+	if column != "id" {
+		query += column + " " + order + ", "
+	}
+	query += "id " + order + " LIMIT ?"
+	args = append(args, opts.Limit)
+
+	if opts.AfterID == 0 && opts.Offset > 0 {
+		query += " OFFSET ?"
+		args = append(args, opts.Offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	goods := make([]storage.Goods, 0, opts.Limit)
+
+	for rows.Next() {
+		var g storage.Goods
+
+		if err := rows.Scan(goodsFields(&g)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		goods = append(goods, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return goods, nil
+}
+
+// eachGoodsPage is how many goods EachGoods reads at a time.
+const eachGoodsPage = 500
+
+// EachGoods calls fn for every goods in id order while reading them from
+// the database a page at a time, so that the whole table is never held in
+// memory. No read is open while fn runs: a slow fn, such as a write to a
+// slow client, would otherwise keep writers out of the database. An error
+// returned by fn stops the iteration and is returned as is.
+func (s *Storage) EachGoods(fn func(storage.Goods) error) error {
+	const op = "storage.sqlite.EachGoods"
+
+	var lastID int64
+
+	for {
+		page, err := goodsPage(s.db, lastID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, g := range page {
+			if err := fn(g); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < eachGoodsPage {
+			return nil
+		}
+
+		lastID = page[len(page)-1].ID
+	}
+}
+
+// goodsPage returns the next eachGoodsPage goods after the id afterID.
+func goodsPage(db *sql.DB, afterID int64) ([]storage.Goods, error) {
+	rows, err := db.Query(
+		"SELECT "+goodsColumns+" FROM goods g WHERE g.deleted_at IS NULL AND g.id > ? ORDER BY g.id LIMIT ?",
+		afterID, eachGoodsPage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goods := make([]storage.Goods, 0, eachGoodsPage)
+
+	for rows.Next() {
+		var g storage.Goods
+
+		if err := rows.Scan(goodsFields(&g)...); err != nil {
+			return nil, err
+		}
+
+		goods = append(goods, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goods, nil
+}
+
+func goodsSortColumn(sortBy string) string {
+	switch sortBy {
+	case "price":
+		return "price"
+	case "title":
+		return "title"
+	default:
+		return "id"
+	}
+}
+
+// DeleteGoods moves the goods to the trash and records it in the goods
+// history as deleted by actor.
+func (s *Storage) DeleteGoods(id int64, actor string) error {
+	const op = "storage.sqlite.DeleteGoods"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	old, err := getGoods(tx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec("UPDATE goods SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordGoodsHistory(tx, storage.GoodsDeleted, actor, &old, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateGoods replaces all fields of the goods if its stored version still
+// equals version, and returns the goods with the incremented version. The
+// change is recorded in the goods history as made by actor.
+func (s *Storage) UpdateGoods(goods storage.Goods, version int64, actor string) (storage.Goods, error) {
+	const op = "storage.sqlite.UpdateGoods"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	old, err := getGoods(tx, goods.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Goods{}, storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if old.Version != version {
+		return storage.Goods{}, storage.ErrGoodsVersionMismatch
+	}
+
+	goods, err = updateGoods(tx, goods, version)
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordGoodsHistory(tx, storage.GoodsUpdated, actor, &old, &goods); err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return goods, nil
+}
+
+// SetGoodsPrice sets the price of the goods in a currency other than its
+// own. The price in the goods currency is part of the goods itself.
+func (s *Storage) SetGoodsPrice(goodsID int64, price storage.Price) error {
+	const op = "storage.sqlite.SetGoodsPrice"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var currency string
+
+	err = tx.QueryRow("SELECT currency FROM goods WHERE id = ? AND deleted_at IS NULL", goodsID).Scan(&currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if currency == price.Currency {
+		return storage.ErrBaseCurrencyPrice
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO goods_prices(goods_id, currency, amount) VALUES (?, ?, ?)
+		ON CONFLICT(goods_id, currency) DO UPDATE SET amount = excluded.amount`,
+		goodsID, price.Currency, price.Amount)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteGoodsPrice(goodsID int64, currency string) error {
+	const op = "storage.sqlite.DeleteGoodsPrice"
+
+	stmt, err := s.db.Prepare("DELETE FROM goods_prices WHERE goods_id = ? AND currency = ?")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(goodsID, currency)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrPriceNotFound
+	}
+
+	return nil
+}
+
+// ListGoodsPrices returns the prices of the goods in other currencies.
+func (s *Storage) ListGoodsPrices(goodsID int64) ([]storage.Price, error) {
+	const op = "storage.sqlite.ListGoodsPrices"
+
+	rows, err := s.db.Query("SELECT currency, amount FROM goods_prices WHERE goods_id = ? ORDER BY currency", goodsID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	prices := []storage.Price{}
+
+	for rows.Next() {
+		var p storage.Price
+
+		if err := rows.Scan(&p.Currency, &p.Amount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		prices = append(prices, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return prices, nil
+}
+
+func getGoods(q queryRower, id int64) (storage.Goods, error) {
+	var goods storage.Goods
+
+	err := q.QueryRow("SELECT "+goodsColumns+" FROM goods g WHERE g.id = ? AND g.deleted_at IS NULL", id).Scan(goodsFields(&goods)...)
+	if err != nil {
+		return storage.Goods{}, err
+	}
+
+	return goods, nil
+}
+
+// updateGoods writes all fields of the goods, which must be at version,
+// and returns it with the incremented version. Goods in the trash are
+// restored. A price set in the new currency of the goods is dropped, as
+// the goods price is in its own currency.
+func updateGoods(tx *sql.Tx, goods storage.Goods, version int64) (storage.Goods, error) {
+	_, err := tx.Exec(`
+		UPDATE goods
+		SET title = ?, price = ?, currency = ?, description = ?, imgUrl = ?, image_id = ?, weight = ?,
+			version = version + 1, deleted_at = NULL
+		WHERE id = ?`,
+		goods.Title, goods.Price, goods.Currency, goods.Description, goods.ImgUrl, nullID(goods.ImageID), goods.Weight, goods.ID)
+	if isForeignKeyErr(err) {
+		return storage.Goods{}, storage.ErrImageNotFound
+	}
+	if err != nil {
+		return storage.Goods{}, err
+	}
+
+	_, err = tx.Exec("DELETE FROM goods_prices WHERE goods_id = ? AND currency = ?", goods.ID, goods.Currency)
+	if err != nil {
+		return storage.Goods{}, err
+	}
+
+	goods.Version = version + 1
+
+	return goods, nil
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/storage"
+)
+
+func saveTestGoods(t *testing.T, s *Storage, n int) []storage.Goods {
+	t.Helper()
+
+	goods := make([]storage.Goods, 0, n)
+
+	for i := 0; i < n; i++ {
+		id, err := s.SaveGoods(storage.Goods{Title: "Goods", Price: 100, Currency: "USD", Weight: 100}, "test")
+		require.NoError(t, err)
+
+		g, err := getGoods(s.db, id)
+		require.NoError(t, err)
+
+		goods = append(goods, g)
+	}
+
+	return goods
+}
+
+// concurrently runs f for every index up to n at once and collects errors.
+func concurrently(n int, f func(i int) error) []error {
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			errs[i] = f(i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+func TestUpdateDeleteGoodsConcurrently(t *testing.T) {
+	const n = 16
+
+	s := newTestStorage(t)
+	goods := saveTestGoods(t, s, n)
+
+	errs := concurrently(n, func(i int) error {
+		g := goods[i]
+		g.Title = "Updated"
+
+		if _, err := s.UpdateGoods(g, g.Version, "test"); err != nil {
+			return err
+		}
+
+		return s.DeleteGoods(g.ID, "test")
+	})
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestSetGoodsPriceConcurrently(t *testing.T) {
+	const n = 16
+
+	s := newTestStorage(t)
+	goods := saveTestGoods(t, s, n)
+
+	errs := concurrently(n, func(i int) error {
+		return s.SetGoodsPrice(goods[i].ID, storage.Price{Currency: "EUR", Amount: int64(100 + i)})
+	})
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestEachGoodsPages(t *testing.T) {
+	s := newTestStorage(t)
+
+	goods := make([]storage.Goods, eachGoodsPage+1)
+	for i := range goods {
+		goods[i] = storage.Goods{Title: "Goods", Price: 100, Currency: "USD", Weight: 100}
+	}
+
+	_, err := s.SaveGoodsBatch(goods, "test")
+	require.NoError(t, err)
+
+	count := 0
+
+	err = s.EachGoods(func(g storage.Goods) error {
+		count++
+
+		// writers are not kept out while the goods are handed over
+		if count == 1 {
+			_, err := s.SaveGoods(storage.Goods{Title: "Late", Price: 100, Currency: "USD", Weight: 100}, "test")
+
+			return err
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the goods saved meanwhile is on the last page
+	assert.Equal(t, eachGoodsPage+2, count)
+}
+
+func TestUpdateGoodsCurrency(t *testing.T) {
+	s := newTestStorage(t)
+	g := saveTestGoods(t, s, 1)[0]
+
+	require.NoError(t, s.SetGoodsPrice(g.ID, storage.Price{Currency: "EUR", Amount: 90}))
+	require.NoError(t, s.SetGoodsPrice(g.ID, storage.Price{Currency: "GBP", Amount: 80}))
+
+	g.Currency, g.Price = "EUR", 95
+
+	_, err := s.UpdateGoods(g, g.Version, "test")
+	require.NoError(t, err)
+
+	prices, err := s.ListGoodsPrices(g.ID)
+	require.NoError(t, err)
+
+	// the EUR price is now the goods price itself
+	assert.Equal(t, []storage.Price{{Currency: "GBP", Amount: 80}}, prices)
+}
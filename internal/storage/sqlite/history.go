@@ -0,0 +1,244 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go-api/internal/storage"
+)
+
+// goodsValues are the fields of the goods kept in the goods history.
+type goodsValues struct {
+	Title       string `json:"title"`
+	Price       int64  `json:"price"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	ImgUrl      string `json:"imgUrl"`
+	ImageID     int64  `json:"imageId,omitempty"`
+	Weight      int32  `json:"weight"`
+}
+
+// ListGoodsHistory returns the history of the goods, newest first. The
+// history of deleted goods is kept.
+func (s *Storage) ListGoodsHistory(goodsID int64, limit int, offset int) ([]storage.GoodsRevision, error) {
+	const op = "storage.sqlite.ListGoodsHistory"
+
+	rows, err := s.db.Query(`
+		SELECT id, goods_id, action, actor, version, old_values, new_values, created_at
+		FROM goods_history
+		WHERE goods_id = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?`, goodsID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	revisions := make([]storage.GoodsRevision, 0, limit)
+
+	for rows.Next() {
+		rev, err := scanGoodsRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+// RestoreGoods brings the goods back to the state recorded by a revision
+// of its history: the state after the change, or the state before it for
+// a deletion. Goods in the trash are taken out of it; purged goods are
+// recreated with their old id, but without the categories, prices and
+// variants they had. The restore is recorded in the history as made by
+// actor.
+func (s *Storage) RestoreGoods(goodsID int64, revisionID int64, actor string) (storage.Goods, error) {
+	const op = "storage.sqlite.RestoreGoods"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rev, err := scanGoodsRevision(tx.QueryRow(`
+		SELECT id, goods_id, action, actor, version, old_values, new_values, created_at
+		FROM goods_history
+		WHERE id = ? AND goods_id = ?`, revisionID, goodsID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Goods{}, storage.ErrGoodsRevisionNotFound
+	}
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	goods := rev.New
+	if goods == nil {
+		goods = rev.Old
+	}
+
+	var (
+		current storage.Goods
+		trashed bool
+		old     *storage.Goods
+	)
+
+	err = tx.QueryRow("SELECT "+goodsColumns+", g.deleted_at IS NOT NULL FROM goods g WHERE g.id = ?", goodsID).
+		Scan(append(goodsFields(&current), &trashed)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		*goods, err = insertGoods(tx, *goods)
+	} else if err == nil {
+		// goods in the trash are recorded as recreated, like purged ones
+		if !trashed {
+			old = &current
+		}
+
+		*goods, err = updateGoods(tx, *goods, current.Version)
+	}
+	if errors.Is(err, storage.ErrImageNotFound) {
+		return storage.Goods{}, err
+	}
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordGoodsHistory(tx, storage.GoodsRestored, actor, old, goods); err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return *goods, nil
+}
+
+// insertGoods recreates purged goods with its id and a version above any
+// it had before.
+func insertGoods(tx *sql.Tx, goods storage.Goods) (storage.Goods, error) {
+	err := tx.QueryRow("SELECT COALESCE(MAX(version), 0) + 1 FROM goods_history WHERE goods_id = ?", goods.ID).
+		Scan(&goods.Version)
+	if err != nil {
+		return storage.Goods{}, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO goods(id, title, price, currency, description, imgUrl, image_id, weight, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		goods.ID, goods.Title, goods.Price, goods.Currency, goods.Description, goods.ImgUrl, nullID(goods.ImageID),
+		goods.Weight, goods.Version)
+	if isForeignKeyErr(err) {
+		return storage.Goods{}, storage.ErrImageNotFound
+	}
+	if err != nil {
+		return storage.Goods{}, err
+	}
+
+	return goods, nil
+}
+
+// recordGoodsHistory adds an entry to the goods history. before is nil for
+// created goods and after is nil for deleted ones.
+func recordGoodsHistory(tx *sql.Tx, action string, actor string, before *storage.Goods, after *storage.Goods) error {
+	goods := after
+	if goods == nil {
+		goods = before
+	}
+
+	oldValues, err := marshalGoodsValues(before)
+	if err != nil {
+		return err
+	}
+
+	newValues, err := marshalGoodsValues(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO goods_history(goods_id, action, actor, version, old_values, new_values)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		goods.ID, action, actor, goods.Version, oldValues, newValues)
+
+	return err
+}
+
+func marshalGoodsValues(g *storage.Goods) (sql.NullString, error) {
+	if g == nil {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(goodsValues{
+		Title:       g.Title,
+		Price:       g.Price,
+		Currency:    g.Currency,
+		Description: g.Description,
+		ImgUrl:      g.ImgUrl,
+		ImageID:     g.ImageID,
+		Weight:      g.Weight,
+	})
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func scanGoodsRevision(row scanner) (storage.GoodsRevision, error) {
+	var (
+		rev                  storage.GoodsRevision
+		oldValues, newValues sql.NullString
+	)
+
+	err := row.Scan(&rev.ID, &rev.GoodsID, &rev.Action, &rev.Actor, &rev.Version, &oldValues, &newValues, &rev.CreatedAt)
+	if err != nil {
+		return storage.GoodsRevision{}, err
+	}
+
+	// the version of the old values is not kept; it is below that of the
+	// new ones unless the goods were deleted
+	oldVersion := rev.Version - 1
+	if !newValues.Valid {
+		oldVersion = rev.Version
+	}
+
+	if rev.Old, err = unmarshalGoodsValues(oldValues, rev.GoodsID, oldVersion); err != nil {
+		return storage.GoodsRevision{}, err
+	}
+	if rev.New, err = unmarshalGoodsValues(newValues, rev.GoodsID, rev.Version); err != nil {
+		return storage.GoodsRevision{}, err
+	}
+
+	return rev, nil
+}
+
+func unmarshalGoodsValues(s sql.NullString, id int64, version int64) (*storage.Goods, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+
+	var v goodsValues
+
+	if err := json.Unmarshal([]byte(s.String), &v); err != nil {
+		return nil, err
+	}
+
+	return &storage.Goods{
+		ID:          id,
+		Title:       v.Title,
+		Price:       v.Price,
+		Currency:    v.Currency,
+		Description: v.Description,
+		ImgUrl:      v.ImgUrl,
+		ImageID:     v.ImageID,
+		Weight:      v.Weight,
+		Version:     version,
+	}, nil
+}
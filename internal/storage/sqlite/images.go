@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-api/internal/storage"
+)
+
+func (s *Storage) SaveImage(image storage.Image) (int64, error) {
+	const op = "storage.sqlite.SaveImage"
+
+	stmt, err := s.db.Prepare("INSERT INTO images(key, content_type, size, width, height, thumbnails) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(image.Key, image.ContentType, image.Size, image.Width, image.Height, formatSizes(image.Thumbnails))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetImage(id int64) (storage.Image, error) {
+	const op = "storage.sqlite.GetImage"
+
+	stmt, err := s.db.Prepare("SELECT id, key, content_type, size, width, height, thumbnails, created_at FROM images WHERE id = ?")
+	if err != nil {
+		return storage.Image{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var (
+		image      storage.Image
+		thumbnails string
+	)
+
+	err = stmt.QueryRow(id).Scan(&image.ID, &image.Key, &image.ContentType, &image.Size,
+		&image.Width, &image.Height, &thumbnails, &image.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Image{}, storage.ErrImageNotFound
+	}
+	if err != nil {
+		return storage.Image{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	image.Thumbnails, err = parseSizes(thumbnails)
+	if err != nil {
+		return storage.Image{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return image, nil
+}
+
+// formatSizes stores thumbnail sizes as a comma separated list.
+func formatSizes(sizes []int) string {
+	parts := make([]string, 0, len(sizes))
+
+	for _, size := range sizes {
+		parts = append(parts, strconv.Itoa(size))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func parseSizes(s string) ([]int, error) {
+	sizes := []int{}
+
+	if s == "" {
+		return sizes, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		size, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+
+		sizes = append(sizes, size)
+	}
+
+	return sizes, nil
+}
@@ -0,0 +1,405 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations are applied in order on top of the tables created in New.
+// PRAGMA user_version holds the number of migrations already applied,
+// so new schema changes must only ever be appended to this list.
+var migrations = []string{
+	// goods: version column for optimistic concurrency (ETag / If-Match)
+	`ALTER TABLE goods ADD COLUMN version INTEGER NOT NULL DEFAULT 1;`,
+
+	// goods: full-text index; moved to setupSearch since it needs FTS5,
+	// which go-sqlite3 only has when built with the sqlite_fts5 tag
+	`SELECT 1;`,
+
+	// categories: tree of categories and many-to-many assignment of goods
+	`
+	CREATE TABLE categories(
+		id INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		parent_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT);
+	CREATE INDEX idx_category_parent ON categories(parent_id);
+
+	CREATE TABLE goods_categories(
+		goods_id INTEGER NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+		PRIMARY KEY (goods_id, category_id));
+	CREATE INDEX idx_goods_category ON goods_categories(category_id);
+	`,
+
+	// goods: stock on hand, reserved quantity and a ledger of every change;
+	// the ledger has no foreign key so it outlives deleted goods
+	`
+	ALTER TABLE goods ADD COLUMN stock INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE goods ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE goods ADD COLUMN low_stock_threshold INTEGER NOT NULL DEFAULT 0;
+
+	CREATE TABLE stock_movements(
+		id INTEGER PRIMARY KEY,
+		goods_id INTEGER NOT NULL,
+		kind TEXT NOT NULL,
+		quantity INTEGER NOT NULL,
+		stock_after INTEGER NOT NULL,
+		reserved_after INTEGER NOT NULL,
+		reference TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+	CREATE INDEX idx_stock_movement_goods ON stock_movements(goods_id, id);
+	`,
+
+	// goods: exact prices in minor units of an ISO 4217 currency instead of
+	// REAL; prices saved before currencies existed are taken as USD
+	`
+	ALTER TABLE goods ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE goods ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+	UPDATE goods SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+	ALTER TABLE goods DROP COLUMN price;
+	ALTER TABLE goods RENAME COLUMN price_minor TO price;
+
+	CREATE TABLE goods_prices(
+		goods_id INTEGER NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		currency TEXT NOT NULL,
+		amount INTEGER NOT NULL,
+		PRIMARY KEY (goods_id, currency));
+	`,
+
+	// uploaded images; blobs live outside the database under key
+	`
+	CREATE TABLE images(
+		id INTEGER PRIMARY KEY,
+		key TEXT NOT NULL UNIQUE,
+		content_type TEXT NOT NULL,
+		size INTEGER NOT NULL,
+		width INTEGER NOT NULL,
+		height INTEGER NOT NULL,
+		thumbnails TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+
+	ALTER TABLE goods ADD COLUMN image_id INTEGER REFERENCES images(id) ON DELETE SET NULL;
+	`,
+
+	// goods variants; options are a JSON object with sorted keys, so equal
+	// option sets are equal strings
+	`
+	CREATE TABLE goods_variants(
+		id INTEGER PRIMARY KEY,
+		goods_id INTEGER NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		sku TEXT NOT NULL UNIQUE,
+		options TEXT NOT NULL,
+		price INTEGER,
+		weight INTEGER,
+		stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+		UNIQUE (goods_id, options));
+	CREATE INDEX idx_goods_variant_goods ON goods_variants(goods_id, id);
+	`,
+
+	// carts and orders; an empty sku is a line of goods without variants.
+	// Order items are copies, so orders survive changes to the catalog.
+	`
+	CREATE TABLE carts(
+		id TEXT PRIMARY KEY,
+		currency TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+	CREATE TABLE cart_items(
+		id INTEGER PRIMARY KEY,
+		cart_id TEXT NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+		goods_id INTEGER NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		sku TEXT NOT NULL DEFAULT '',
+		quantity INTEGER NOT NULL CHECK (quantity > 0),
+		UNIQUE (cart_id, goods_id, sku));
+	CREATE TABLE orders(
+		id INTEGER PRIMARY KEY,
+		status TEXT NOT NULL,
+		currency TEXT NOT NULL,
+		total INTEGER NOT NULL,
+		weight INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+	CREATE INDEX idx_orders_status ON orders(status, id);
+	CREATE TABLE order_items(
+		id INTEGER PRIMARY KEY,
+		order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+		goods_id INTEGER NOT NULL,
+		sku TEXT NOT NULL,
+		title TEXT NOT NULL,
+		price INTEGER NOT NULL,
+		weight INTEGER NOT NULL,
+		quantity INTEGER NOT NULL);
+	CREATE INDEX idx_order_items_order ON order_items(order_id);
+	`,
+
+	// promotions; a promotion without categories and goods applies to the
+	// whole catalog
+	`
+	CREATE TABLE promotions(
+		id INTEGER PRIMARY KEY,
+		code TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		kind TEXT NOT NULL,
+		value INTEGER NOT NULL,
+		currency TEXT NOT NULL DEFAULT '',
+		starts_at TIMESTAMP,
+		ends_at TIMESTAMP,
+		usage_limit INTEGER NOT NULL DEFAULT 0,
+		used INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+	CREATE TABLE promotion_categories(
+		promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+		category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+		PRIMARY KEY (promotion_id, category_id));
+	CREATE TABLE promotion_goods(
+		promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+		goods_id INTEGER NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		PRIMARY KEY (promotion_id, goods_id));
+
+	ALTER TABLE orders ADD COLUMN discount INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE orders ADD COLUMN promotion_code TEXT NOT NULL DEFAULT '';
+	`,
+
+	// goods: audit trail of every insert, update, delete and restore with
+	// the fields before and after as JSON; no foreign key so that the
+	// history of deleted goods is kept and they can be restored
+	`
+	CREATE TABLE goods_history(
+		id INTEGER PRIMARY KEY,
+		goods_id INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		version INTEGER NOT NULL,
+		old_values TEXT,
+		new_values TEXT,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+	CREATE INDEX idx_goods_history_goods ON goods_history(goods_id, id);
+	`,
+
+	// url, goods: soft delete; rows with deleted_at set are in the trash
+	// and hidden from every read until restored or purged
+	`
+	ALTER TABLE url ADD COLUMN deleted_at TIMESTAMP;
+	CREATE INDEX idx_url_deleted_at ON url(deleted_at);
+
+	ALTER TABLE goods ADD COLUMN deleted_at TIMESTAMP;
+	CREATE INDEX idx_goods_deleted_at ON goods(deleted_at);
+	`,
+
+	// reviews: ratings of goods, hidden until approved by a moderator
+	`
+	CREATE TABLE reviews(
+		id INTEGER PRIMARY KEY,
+		goods_id INTEGER NOT NULL REFERENCES goods(id) ON DELETE CASCADE,
+		author TEXT NOT NULL,
+		rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+		text TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'pending',
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+	CREATE INDEX idx_reviews_goods ON reviews(goods_id, status, id);
+	CREATE INDEX idx_reviews_status ON reviews(status, id);
+	`,
+
+	// url: redirects for the url stats; keyed by alias rather than url id
+	// so that recording a click needs no lookup
+	`
+	CREATE TABLE clicks(
+		id INTEGER PRIMARY KEY,
+		alias TEXT NOT NULL,
+		clicked_at TIMESTAMP NOT NULL,
+		referrer TEXT NOT NULL DEFAULT '',
+		user_agent TEXT NOT NULL DEFAULT '',
+		ip_hash TEXT NOT NULL DEFAULT '');
+	CREATE INDEX idx_clicks_alias ON clicks(alias, clicked_at);
+	`,
+
+	// url: expiry by time and by number of clicks
+	`
+	ALTER TABLE url ADD COLUMN expires_at TIMESTAMP;
+	ALTER TABLE url ADD COLUMN max_clicks INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE url ADD COLUMN click_count INTEGER NOT NULL DEFAULT 0;
+	CREATE INDEX idx_url_expires_at ON url(expires_at);
+	`,
+
+	// url: bcrypt hash of the password of protected links
+	`
+	ALTER TABLE url ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
+	`,
+
+	// url: versions for updates in place, and the previous targets of
+	// each url so that they can be rolled back to
+	`
+	ALTER TABLE url ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+	CREATE TABLE url_history(
+		id INTEGER PRIMARY KEY,
+		url_id INTEGER NOT NULL REFERENCES url(id) ON DELETE CASCADE,
+		version INTEGER NOT NULL,
+		url TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		replaced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (url_id, version));
+	`,
+
+	// url: creation time for the url listing; urls saved before it are
+	// taken as created by the migration
+	`
+	ALTER TABLE url ADD COLUMN created_at TIMESTAMP;
+	UPDATE url SET created_at = CURRENT_TIMESTAMP;
+	CREATE INDEX idx_url_created_at ON url(created_at);
+	`,
+
+	// url: AUTOINCREMENT ids, so that the id of a purged url, and the alias
+	// generated from it, are never given to another url. SQLite can't add
+	// it to a table, so url is rebuilt; url_history is copied aside
+	// meanwhile, since dropping url would empty it by cascade
+	`
+	CREATE TABLE url_history_old AS SELECT * FROM url_history;
+	DROP TABLE url_history;
+
+	CREATE TABLE url_new(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		alias TEXT NOT NULL UNIQUE,
+		url TEXT NOT NULL,
+		deleted_at TIMESTAMP,
+		expires_at TIMESTAMP,
+		max_clicks INTEGER NOT NULL DEFAULT 0,
+		click_count INTEGER NOT NULL DEFAULT 0,
+		password_hash TEXT NOT NULL DEFAULT '',
+		version INTEGER NOT NULL DEFAULT 1,
+		created_at TIMESTAMP);
+	INSERT INTO url_new(id, alias, url, deleted_at, expires_at, max_clicks, click_count, password_hash, version, created_at)
+		SELECT id, alias, url, deleted_at, expires_at, max_clicks, click_count, password_hash, version, created_at FROM url;
+	DROP TABLE url;
+	ALTER TABLE url_new RENAME TO url;
+	CREATE INDEX idx_alias ON url(alias);
+	CREATE INDEX idx_url_deleted_at ON url(deleted_at);
+	CREATE INDEX idx_url_expires_at ON url(expires_at);
+	CREATE INDEX idx_url_created_at ON url(created_at);
+
+	CREATE TABLE url_history(
+		id INTEGER PRIMARY KEY,
+		url_id INTEGER NOT NULL REFERENCES url(id) ON DELETE CASCADE,
+		version INTEGER NOT NULL,
+		url TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		replaced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (url_id, version));
+	INSERT INTO url_history(id, url_id, version, url, actor, replaced_at)
+		SELECT id, url_id, version, url, actor, replaced_at FROM url_history_old;
+	DROP TABLE url_history_old;
+	`,
+}
+
+func migrate(db *sql.DB) error {
+	const op = "storage.sqlite.migrate"
+
+	var version int
+
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("%s: migration %d: %w", op, i+1, err)
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// setupSearch keeps the full-text index of goods if SQLite has FTS5, and
+// reports whether it does. Without FTS5 the triggers that fill the index
+// are dropped, so that goods can still be written, and search falls back
+// to LIKE; the index is rebuilt once FTS5 is back.
+func setupSearch(db *sql.DB) (bool, error) {
+	const op = "storage.sqlite.setupSearch"
+
+	var fts bool
+
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !fts {
+		_, err := db.Exec(`
+			DROP TRIGGER IF EXISTS goods_fts_ai;
+			DROP TRIGGER IF EXISTS goods_fts_ad;
+			DROP TRIGGER IF EXISTS goods_fts_au;
+		`)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return false, nil
+	}
+
+	var synced bool
+
+	err := db.QueryRow("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'trigger' AND name = 'goods_fts_ai'").
+		Scan(&synced)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if synced {
+		return true, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(goodsSearchIndex); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return true, nil
+}
+
+// goodsSearchIndex is the full-text index over goods titles and
+// descriptions, kept in sync by triggers.
+const goodsSearchIndex = `
+	CREATE VIRTUAL TABLE IF NOT EXISTS goods_fts USING fts5(
+		title,
+		description,
+		content='goods',
+		content_rowid='id',
+		tokenize='unicode61 remove_diacritics 2');
+
+	CREATE TRIGGER goods_fts_ai AFTER INSERT ON goods BEGIN
+		INSERT INTO goods_fts(rowid, title, description) VALUES (new.id, new.title, new.description);
+	END;
+
+	CREATE TRIGGER goods_fts_ad AFTER DELETE ON goods BEGIN
+		INSERT INTO goods_fts(goods_fts, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+	END;
+
+	CREATE TRIGGER goods_fts_au AFTER UPDATE OF title, description ON goods BEGIN
+		INSERT INTO goods_fts(goods_fts, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+		INSERT INTO goods_fts(rowid, title, description) VALUES (new.id, new.title, new.description);
+	END;
+
+	INSERT INTO goods_fts(goods_fts) VALUES ('rebuild');
+`
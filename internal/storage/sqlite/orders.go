@@ -0,0 +1,253 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go-api/internal/storage"
+)
+
+// PlaceOrder turns the cart into a pending order with the prices and
+// weights the catalog has at this moment, and deletes the cart. A non-empty
+// code applies that promotion and counts one use of it.
+func (s *Storage) PlaceOrder(cartID string, code string) (storage.Order, error) {
+	const op = "storage.sqlite.PlaceOrder"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	cart, err := getCart(tx, cartID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Order{}, storage.ErrCartNotFound
+	}
+	if isStaleCartItemErr(err) {
+		return storage.Order{}, err
+	}
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(cart.Items) == 0 {
+		return storage.Order{}, storage.ErrCartEmpty
+	}
+
+	total, weight := cart.Total()
+
+	var discount int64
+
+	if code != "" {
+		promotion, err := getPromotion(tx, code)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.Order{}, storage.ErrPromotionNotFound
+		}
+		if err != nil {
+			return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		discounts, err := applyPromotion(tx, promotion, cart.Currency, cart.Items)
+		if isPromotionErr(err) {
+			return storage.Order{}, err
+		}
+		if err != nil {
+			return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, d := range discounts {
+			discount += d
+		}
+
+		// guarded, as another order may have used the promotion up meanwhile
+		res, err := tx.Exec("UPDATE promotions SET used = used + 1 WHERE id = ? AND (usage_limit = 0 OR used < usage_limit)", promotion.ID)
+		if err != nil {
+			return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return storage.Order{}, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+		}
+		if affected == 0 {
+			return storage.Order{}, storage.ErrPromotionUsedUp
+		}
+
+		code = promotion.Code
+	}
+
+	res, err := tx.Exec("INSERT INTO orders(status, currency, total, discount, promotion_code, weight) VALUES (?, ?, ?, ?, ?, ?)",
+		storage.OrderPending, cart.Currency, total-discount, discount, code, weight)
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO order_items(order_id, goods_id, sku, title, price, weight, quantity)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, item := range cart.Items {
+		_, err := stmt.Exec(id, item.GoodsID, item.SKU, item.Title, item.Price, item.Weight, item.Quantity)
+		if err != nil {
+			return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM carts WHERE id = ?", cartID); err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order, err := getOrder(tx, id)
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return order, nil
+}
+
+func (s *Storage) GetOrder(id int64) (storage.Order, error) {
+	const op = "storage.sqlite.GetOrder"
+
+	order, err := getOrder(s.db, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Order{}, storage.ErrOrderNotFound
+	}
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return order, nil
+}
+
+// ListOrders returns orders without their items, newest first. An empty
+// status lists orders in every status.
+func (s *Storage) ListOrders(status string, limit int, offset int) ([]storage.Order, error) {
+	const op = "storage.sqlite.ListOrders"
+
+	rows, err := s.db.Query(`
+		SELECT id, status, currency, total, discount, promotion_code, weight, created_at, updated_at
+		FROM orders
+		WHERE ?1 = '' OR status = ?1
+		ORDER BY id DESC
+		LIMIT ?2 OFFSET ?3`, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	orders := make([]storage.Order, 0, limit)
+
+	for rows.Next() {
+		var o storage.Order
+
+		err := rows.Scan(&o.ID, &o.Status, &o.Currency, &o.Total, &o.Discount, &o.PromotionCode, &o.Weight,
+			&o.CreatedAt, &o.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		orders = append(orders, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+// SetOrderStatus moves the order to status if storage.CanChangeOrderStatus
+// allows it. Everything but the status of an order is immutable.
+func (s *Storage) SetOrderStatus(id int64, status string) (storage.Order, error) {
+	const op = "storage.sqlite.SetOrderStatus"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var current string
+
+	err = tx.QueryRow("SELECT status FROM orders WHERE id = ?", id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Order{}, storage.ErrOrderNotFound
+	}
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !storage.CanChangeOrderStatus(current, status) {
+		return storage.Order{}, fmt.Errorf("%w: %s to %s", storage.ErrOrderStatus, current, status)
+	}
+
+	_, err = tx.Exec("UPDATE orders SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", status, id)
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order, err := getOrder(tx, id)
+	if err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.Order{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return order, nil
+}
+
+func getOrder(q querier, id int64) (storage.Order, error) {
+	var o storage.Order
+
+	err := q.QueryRow(`
+		SELECT id, status, currency, total, discount, promotion_code, weight, created_at, updated_at
+		FROM orders WHERE id = ?`, id).
+		Scan(&o.ID, &o.Status, &o.Currency, &o.Total, &o.Discount, &o.PromotionCode, &o.Weight,
+			&o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return storage.Order{}, err
+	}
+
+	rows, err := q.Query(`
+		SELECT id, goods_id, sku, title, price, weight, quantity
+		FROM order_items
+		WHERE order_id = ?
+		ORDER BY id`, id)
+	if err != nil {
+		return storage.Order{}, err
+	}
+	defer rows.Close()
+
+	o.Items = []storage.LineItem{}
+
+	for rows.Next() {
+		var item storage.LineItem
+
+		err := rows.Scan(&item.ID, &item.GoodsID, &item.SKU, &item.Title, &item.Price, &item.Weight, &item.Quantity)
+		if err != nil {
+			return storage.Order{}, err
+		}
+
+		o.Items = append(o.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return storage.Order{}, err
+	}
+
+	return o, nil
+}
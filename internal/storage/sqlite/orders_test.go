@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-api/internal/storage"
+)
+
+func TestPlaceOrderConcurrently(t *testing.T) {
+	const n = 16
+
+	s := newTestStorage(t)
+	goods := saveTestGoods(t, s, n)
+
+	errs := concurrently(n, func(i int) error {
+		cartID := fmt.Sprintf("cart-%d", i)
+
+		if err := s.SaveCart(cartID, "USD"); err != nil {
+			return err
+		}
+
+		if _, err := s.AddCartItem(cartID, storage.LineItem{GoodsID: goods[i].ID, Quantity: 2}); err != nil {
+			return err
+		}
+
+		_, err := s.PlaceOrder(cartID, "")
+
+		return err
+	})
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
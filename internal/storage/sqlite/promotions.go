@@ -0,0 +1,398 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-api/internal/storage"
+)
+
+const promotionColumns = "id, code, kind, value, currency, starts_at, ends_at, usage_limit, used, created_at"
+
+// promotionScopeQuery selects which of the goods in the IN list, filled in
+// by the caller, are in the scope of promotion ?1: listed directly or in a
+// listed category or its subcategories.
+const promotionScopeQuery = `
+	WITH RECURSIVE scope(id) AS (
+		SELECT category_id FROM promotion_categories WHERE promotion_id = ?1
+		UNION
+		SELECT c.id FROM categories c JOIN scope s ON c.parent_id = s.id
+	)
+	SELECT goods_id FROM promotion_goods WHERE promotion_id = ?1 AND goods_id IN (%[1]s)
+	UNION
+	SELECT goods_id FROM goods_categories WHERE category_id IN (SELECT id FROM scope) AND goods_id IN (%[1]s)`
+
+func (s *Storage) SavePromotion(promotion storage.Promotion) (int64, error) {
+	const op = "storage.sqlite.SavePromotion"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(`
+		INSERT INTO promotions(code, kind, value, currency, starts_at, ends_at, usage_limit)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		promotion.Code, promotion.Kind, promotion.Value, promotion.Currency,
+		nullTime(promotion.StartsAt), nullTime(promotion.EndsAt), promotion.UsageLimit)
+	if isUniqueErr(err) {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrPromotionExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	for _, categoryID := range promotion.CategoryIDs {
+		_, err := tx.Exec("INSERT OR IGNORE INTO promotion_categories(promotion_id, category_id) VALUES (?, ?)", id, categoryID)
+		if isForeignKeyErr(err) {
+			return 0, storage.ErrCategoryNotFound
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for _, goodsID := range promotion.GoodsIDs {
+		_, err := tx.Exec("INSERT OR IGNORE INTO promotion_goods(promotion_id, goods_id) VALUES (?, ?)", id, goodsID)
+		if isForeignKeyErr(err) {
+			return 0, storage.ErrGoodsNotFound
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GetPromotion finds a promotion by its code, ignoring case.
+func (s *Storage) GetPromotion(code string) (storage.Promotion, error) {
+	const op = "storage.sqlite.GetPromotion"
+
+	promotion, err := getPromotion(s.db, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Promotion{}, storage.ErrPromotionNotFound
+	}
+	if err != nil {
+		return storage.Promotion{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promotion, nil
+}
+
+// ListPromotions returns promotions, newest first.
+func (s *Storage) ListPromotions(limit int, offset int) ([]storage.Promotion, error) {
+	const op = "storage.sqlite.ListPromotions"
+
+	rows, err := s.db.Query("SELECT "+promotionColumns+" FROM promotions ORDER BY id DESC LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	promotions := make([]storage.Promotion, 0, limit)
+
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		promotions = append(promotions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range promotions {
+		if err := promotionScope(s.db, &promotions[i]); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return promotions, nil
+}
+
+func (s *Storage) DeletePromotion(id int64) error {
+	const op = "storage.sqlite.DeletePromotion"
+
+	stmt, err := s.db.Prepare("DELETE FROM promotions WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+// ApplyPromotion returns the promotion with the code and the discount it
+// gives on each of the lines priced in currency, without using it up.
+func (s *Storage) ApplyPromotion(code string, currency string, items []storage.LineItem) (storage.Promotion, []int64, error) {
+	const op = "storage.sqlite.ApplyPromotion"
+
+	promotion, err := getPromotion(s.db, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Promotion{}, nil, storage.ErrPromotionNotFound
+	}
+	if err != nil {
+		return storage.Promotion{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	discounts, err := applyPromotion(s.db, promotion, currency, items)
+	if isPromotionErr(err) {
+		return storage.Promotion{}, nil, err
+	}
+	if err != nil {
+		return storage.Promotion{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return promotion, discounts, nil
+}
+
+// PriceGoods prices the goods in currency the way carts do, one line per
+// distinct goods with the number of times it is listed as the quantity.
+func (s *Storage) PriceGoods(goodsIDs []int64, currency string) ([]storage.LineItem, error) {
+	const op = "storage.sqlite.PriceGoods"
+
+	stmt, err := s.db.Prepare(`
+		SELECT g.id, g.title, g.currency, g.price, g.weight, gp.amount
+		FROM goods g
+		LEFT JOIN goods_prices gp ON gp.goods_id = g.id AND gp.currency = ?
+		WHERE g.id = ? AND g.deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		items []storage.LineItem
+		index = make(map[int64]int, len(goodsIDs))
+	)
+
+	for _, id := range goodsIDs {
+		if i, ok := index[id]; ok {
+			items[i].Quantity++
+
+			continue
+		}
+
+		var (
+			item          storage.LineItem
+			goodsCurrency string
+			listPrice     sql.NullInt64
+		)
+
+		err := stmt.QueryRow(currency, id).
+			Scan(&item.GoodsID, &item.Title, &goodsCurrency, &item.Price, &item.Weight, &listPrice)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", storage.ErrGoodsNotFound, id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if goodsCurrency != currency {
+			if !listPrice.Valid {
+				return nil, fmt.Errorf("%w: goods %d in %s", storage.ErrPriceNotFound, id, currency)
+			}
+
+			item.Price = listPrice.Int64
+		}
+
+		item.Quantity = 1
+
+		index[id] = len(items)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// applyPromotion checks the promotion against the lines priced in
+// currency and returns the discount on each line.
+func applyPromotion(q querier, promotion storage.Promotion, currency string, items []storage.LineItem) ([]int64, error) {
+	if err := promotion.Check(time.Now(), currency); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.GoodsID)
+	}
+
+	eligible, err := promotionGoods(q, promotion, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make([]int64, len(items))
+	found := false
+
+	for i, item := range items {
+		if eligible[item.GoodsID] {
+			amounts[i] = item.Price * item.Quantity
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, storage.ErrPromotionNoGoods
+	}
+
+	return promotion.Discounts(amounts), nil
+}
+
+func promotionGoods(q querier, promotion storage.Promotion, goodsIDs []int64) (map[int64]bool, error) {
+	eligible := make(map[int64]bool, len(goodsIDs))
+
+	if !promotion.Scoped() {
+		for _, id := range goodsIDs {
+			eligible[id] = true
+		}
+
+		return eligible, nil
+	}
+
+	if len(goodsIDs) == 0 {
+		return eligible, nil
+	}
+
+	args := make([]any, 0, len(goodsIDs)+1)
+	args = append(args, promotion.ID)
+	for _, id := range goodsIDs {
+		args = append(args, id)
+	}
+
+	// numbered from ?2 as ?1 is the promotion and the list appears twice
+	placeholders := make([]string, 0, len(goodsIDs))
+	for i := range goodsIDs {
+		placeholders = append(placeholders, fmt.Sprintf("?%d", i+2))
+	}
+
+	rows, err := q.Query(fmt.Sprintf(promotionScopeQuery, strings.Join(placeholders, ", ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		eligible[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return eligible, nil
+}
+
+func getPromotion(q querier, code string) (storage.Promotion, error) {
+	promotion, err := scanPromotion(q.QueryRow("SELECT "+promotionColumns+" FROM promotions WHERE code = ?", code))
+	if err != nil {
+		return storage.Promotion{}, err
+	}
+
+	if err := promotionScope(q, &promotion); err != nil {
+		return storage.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPromotion(row scanner) (storage.Promotion, error) {
+	var (
+		p                storage.Promotion
+		startsAt, endsAt sql.NullTime
+	)
+
+	err := row.Scan(&p.ID, &p.Code, &p.Kind, &p.Value, &p.Currency, &startsAt, &endsAt,
+		&p.UsageLimit, &p.Used, &p.CreatedAt)
+	if err != nil {
+		return storage.Promotion{}, err
+	}
+
+	p.StartsAt = startsAt.Time
+	p.EndsAt = endsAt.Time
+
+	return p, nil
+}
+
+func promotionScope(q querier, p *storage.Promotion) error {
+	var err error
+
+	p.CategoryIDs, err = queryIDs(q, "SELECT category_id FROM promotion_categories WHERE promotion_id = ? ORDER BY category_id", p.ID)
+	if err != nil {
+		return err
+	}
+
+	p.GoodsIDs, err = queryIDs(q, "SELECT goods_id FROM promotion_goods WHERE promotion_id = ? ORDER BY goods_id", p.ID)
+
+	return err
+}
+
+func queryIDs(q querier, query string, args ...any) ([]int64, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// isPromotionErr reports whether err is a reason applyPromotion rejects
+// a promotion.
+func isPromotionErr(err error) bool {
+	return errors.Is(err, storage.ErrPromotionInactive) || errors.Is(err, storage.ErrPromotionUsedUp) ||
+		errors.Is(err, storage.ErrPromotionCurrency) || errors.Is(err, storage.ErrPromotionNoGoods)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
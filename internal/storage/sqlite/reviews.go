@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"go-api/internal/storage"
+)
+
+const reviewColumns = "id, goods_id, author, rating, text, status, created_at"
+
+// SaveReview adds a pending review of goods. Goods in the trash cannot be
+// reviewed.
+func (s *Storage) SaveReview(review storage.Review) (storage.Review, error) {
+	const op = "storage.sqlite.SaveReview"
+
+	res, err := s.db.Exec(`
+		INSERT INTO reviews(goods_id, author, rating, text, status)
+		SELECT id, ?, ?, ?, ? FROM goods WHERE id = ? AND deleted_at IS NULL`,
+		review.Author, review.Rating, review.Text, storage.ReviewPending, review.GoodsID)
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return storage.Review{}, storage.ErrGoodsNotFound
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	review, err = scanReview(s.db.QueryRow("SELECT "+reviewColumns+" FROM reviews WHERE id = ?", id))
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return review, nil
+}
+
+// ListReviews returns reviews newest first. A zero goodsID lists the
+// reviews of all goods and an empty status reviews in every status.
+func (s *Storage) ListReviews(goodsID int64, status string, limit int, offset int) ([]storage.Review, error) {
+	const op = "storage.sqlite.ListReviews"
+
+	rows, err := s.db.Query(`
+		SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE (?1 = 0 OR goods_id = ?1) AND (?2 = '' OR status = ?2)
+		ORDER BY id DESC
+		LIMIT ?3 OFFSET ?4`, goodsID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	reviews := make([]storage.Review, 0, limit)
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+// SetReviewStatus moderates a review. Any status may change to any other,
+// so that a moderator can revise their decision.
+func (s *Storage) SetReviewStatus(id int64, status string) (storage.Review, error) {
+	const op = "storage.sqlite.SetReviewStatus"
+
+	res, err := s.db.Exec("UPDATE reviews SET status = ? WHERE id = ?", status, id)
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return storage.Review{}, storage.ErrReviewNotFound
+	}
+
+	review, err := scanReview(s.db.QueryRow("SELECT "+reviewColumns+" FROM reviews WHERE id = ?", id))
+	if err != nil {
+		return storage.Review{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return review, nil
+}
+
+// GetGoodsRating aggregates the approved reviews of goods.
+func (s *Storage) GetGoodsRating(goodsID int64) (storage.Rating, error) {
+	const op = "storage.sqlite.GetGoodsRating"
+
+	var rating storage.Rating
+
+	err := s.db.QueryRow(`
+		SELECT COALESCE(AVG(rating), 0), COUNT(*)
+		FROM reviews
+		WHERE goods_id = ? AND status = ?`, goodsID, storage.ReviewApproved).
+		Scan(&rating.Average, &rating.Count)
+	if err != nil {
+		return storage.Rating{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rating, nil
+}
+
+func scanReview(row scanner) (storage.Review, error) {
+	var r storage.Review
+
+	err := row.Scan(&r.ID, &r.GoodsID, &r.Author, &r.Rating, &r.Text, &r.Status, &r.CreatedAt)
+	if err != nil {
+		return storage.Review{}, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"go-api/internal/storage"
+)
+
+// SQLite marks the matches with the sentinels, which become <mark> tags
+// once the text around them is HTML-escaped by markMatches.
+const (
+	highlightOpen  = "\x01"
+	highlightClose = "\x02"
+	snippetTokens  = 16
+)
+
+var markReplacer = strings.NewReplacer(highlightOpen, "<mark>", highlightClose, "</mark>")
+
+// markMatches HTML-escapes text marked by highlight or snippet and turns
+// the sentinels around the matches into <mark> tags.
+func markMatches(text string) string {
+	return markReplacer.Replace(html.EscapeString(text))
+}
+
+// SearchGoods runs a full-text query over goods titles and descriptions,
+// best matches first. Without FTS5, see setupSearch, goods are matched
+// with LIKE instead, in id order and without highlights.
+func (s *Storage) SearchGoods(opts storage.GoodsSearchOptions) ([]storage.GoodsSearchResult, error) {
+	const op = "storage.sqlite.SearchGoods"
+
+	match := ftsQuery(opts.Query)
+	if match == "" {
+		return []storage.GoodsSearchResult{}, nil
+	}
+
+	filters, filterArgs := searchFilters(opts)
+
+	if !s.fts {
+		results, err := s.searchGoodsLike(opts, filters, filterArgs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return results, nil
+	}
+
+	where := append([]string{"goods_fts MATCH ?"}, filters...)
+	args := append([]any{highlightOpen, highlightClose, highlightOpen, highlightClose, match}, filterArgs...)
+
+	// title matches weigh more than description matches
+	query := fmt.Sprintf(`
+		SELECT `+goodsColumns+`,
+			highlight(goods_fts, 0, ?, ?),
+			COALESCE(snippet(goods_fts, 1, ?, ?, '…', %d), ''),
+			bm25(goods_fts, 10.0, 1.0) AS rank
+		FROM goods_fts
+		JOIN goods g ON g.id = goods_fts.rowid
+		WHERE %s
+		ORDER BY rank, g.id
+		LIMIT ? OFFSET ?`, snippetTokens, strings.Join(where, " AND "))
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	results := make([]storage.GoodsSearchResult, 0, opts.Limit)
+
+	for rows.Next() {
+		var r storage.GoodsSearchResult
+
+		err := rows.Scan(append(goodsFields(&r.Goods), &r.Highlight, &r.Snippet, &r.Rank)...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		r.Highlight, r.Snippet = markMatches(r.Highlight), markMatches(r.Snippet)
+
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// searchGoodsLike finds the goods whose title or description contains
+// every word of the query.
+func (s *Storage) searchGoodsLike(opts storage.GoodsSearchOptions, where []string, args []any) ([]storage.GoodsSearchResult, error) {
+	for _, term := range strings.Fields(opts.Query) {
+		pattern := "%" + escapeLike(term) + "%"
+
+		where = append(where, `(g.title LIKE ? ESCAPE '\' OR g.description LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	query := "SELECT " + goodsColumns + " FROM goods g WHERE " + strings.Join(where, " AND ") +
+		" ORDER BY g.id LIMIT ? OFFSET ?"
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]storage.GoodsSearchResult, 0, opts.Limit)
+
+	for rows.Next() {
+		var r storage.GoodsSearchResult
+
+		if err := rows.Scan(goodsFields(&r.Goods)...); err != nil {
+			return nil, err
+		}
+
+		r.Highlight = html.EscapeString(r.Title)
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// searchFilters returns the conditions of the search on goods g other
+// than the query itself.
+func searchFilters(opts storage.GoodsSearchOptions) ([]string, []any) {
+	where := []string{"g.deleted_at IS NULL"}
+
+	var args []any
+
+	if opts.Currency != "" {
+		where = append(where, "g.currency = ?")
+		args = append(args, opts.Currency)
+	}
+	if opts.MinPrice != nil {
+		where = append(where, "g.price >= ?")
+		args = append(args, *opts.MinPrice)
+	}
+	if opts.MaxPrice != nil {
+		where = append(where, "g.price <= ?")
+		args = append(args, *opts.MaxPrice)
+	}
+	if opts.MinWeight != nil {
+		where = append(where, "g.weight >= ?")
+		args = append(args, *opts.MinWeight)
+	}
+	if opts.MaxWeight != nil {
+		where = append(where, "g.weight <= ?")
+		args = append(args, *opts.MaxWeight)
+	}
+
+	return where, args
+}
+
+// ftsQuery turns user input into an FTS5 query where every word is a
+// quoted prefix term, so that operators and punctuation in the input
+// cannot produce syntax errors.
+func ftsQuery(q string) string {
+	terms := strings.Fields(q)
+
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+
+	return strings.Join(terms, " ")
+}
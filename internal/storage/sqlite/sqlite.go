@@ -5,18 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"go-api/internal/storage"
+	"strings"
+	"time"
 
 	"github.com/mattn/go-sqlite3"
 )
 
 type Storage struct {
 	db *sql.DB
+	// fts is set if goods are searched with the FTS5 index
+	fts bool
 }
 
 func New(storagePath string) (*Storage, error) {
 	const op = "storage.sqlite.New"
 
-	db, err := sql.Open("sqlite3", storagePath)
+	db, err := sql.Open("sqlite3", dsn(storagePath))
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -56,18 +60,45 @@ func New(storagePath string) (*Storage, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &Storage{db: db}, nil
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fts, err := setupSearch(db)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{db: db, fts: fts}, nil
 }
 
-func (s *Storage) SaveURL(urlToSave string, alias string) (int64, error) {
+// dsn enables foreign key enforcement, which SQLite keeps off by default
+// and which has to be requested for every connection, and makes every
+// transaction take the write lock when it begins: a deferred transaction
+// that reads before writing fails with "database is locked" when another
+// one writes in between, instead of waiting for it.
+func dsn(storagePath string) string {
+	sep := "?"
+	if strings.Contains(storagePath, "?") {
+		sep = "&"
+	}
+
+	return storagePath + sep + "_foreign_keys=on&_txlock=immediate"
+}
+
+const insertURL = `
+	INSERT INTO url(url, alias, expires_at, max_clicks, password_hash, created_at)
+	VALUES(?, ?, datetime(?, 'unixepoch'), ?, ?, CURRENT_TIMESTAMP)`
+
+func (s *Storage) SaveURL(url storage.URL) (int64, error) {
 	const op = "storage.sqlite.SaveURL"
 
-	stmt, err := s.db.Prepare("INSERT INTO url(url, alias) VALUES(?, ?)")
+	stmt, err := s.db.Prepare(insertURL)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	res, err := stmt.Exec(urlToSave, alias)
+	res, err := stmt.Exec(url.URL, url.Alias, nullUnix(url.ExpiresAt), url.MaxClicks, url.PasswordHash)
 	if err != nil {
 		if sqliteErr, ok := err.(sqlite3.Error); ok && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
 			return 0, fmt.Errorf("%s: %w", op, storage.ErrURLExists)
@@ -84,17 +115,49 @@ func (s *Storage) SaveURL(urlToSave string, alias string) (int64, error) {
 	return id, nil
 }
 
+// GetURL returns the url to redirect to, or storage.ErrURLExpired once it
+// expired. Each call counts as a click of links limited by MaxClicks.
+// Links with a password are only returned by UnlockURL, GetURL fails
+// with storage.ErrURLProtected for them.
 func (s *Storage) GetURL(ailas string) (string, error) {
 	const op = "storage.sqlite.GetURL"
 
-	stmt, err := s.db.Prepare("SELECT url FROM url WHERE alias = ?")
+	resURL, err := s.visitURL(ailas, false)
+	if errors.Is(err, storage.ErrURLNotFound) ||
+		errors.Is(err, storage.ErrURLExpired) ||
+		errors.Is(err, storage.ErrURLProtected) {
+		return "", err
+	}
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	var resURL string
+	return resURL, nil
+}
 
-	err = stmt.QueryRow(ailas).Scan(&resURL)
+// UnlockURL is GetURL for links with a password, once it is verified
+// against GetURLPasswordHash.
+func (s *Storage) UnlockURL(alias string) (string, error) {
+	const op = "storage.sqlite.UnlockURL"
+
+	resURL, err := s.visitURL(alias, true)
+	if errors.Is(err, storage.ErrURLNotFound) || errors.Is(err, storage.ErrURLExpired) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resURL, nil
+}
+
+// GetURLPasswordHash returns the bcrypt hash of the password of the link,
+// empty for links without one. Expired links fail with
+// storage.ErrURLExpired, so that their password is not asked for.
+func (s *Storage) GetURLPasswordHash(alias string) (string, error) {
+	const op = "storage.sqlite.GetURLPasswordHash"
+
+	u, err := getURL(s.db, alias)
 	if errors.Is(err, sql.ErrNoRows) {
 		return "", storage.ErrURLNotFound
 	}
@@ -102,42 +165,100 @@ func (s *Storage) GetURL(ailas string) (string, error) {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return resURL, nil
+	if u.Expired(time.Now()) {
+		return "", storage.ErrURLExpired
+	}
+
+	return u.PasswordHash, nil
 }
 
-func (s *Storage) DeleteURL(alias string) error {
-	const op = "storage.sqlite.DeleteURL"
+func (s *Storage) visitURL(alias string, unlocked bool) (string, error) {
+	u, err := getURL(s.db, alias)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", storage.ErrURLNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if u.Expired(time.Now()) {
+		return "", storage.ErrURLExpired
+	}
+
+	if u.PasswordHash != "" && !unlocked {
+		return "", storage.ErrURLProtected
+	}
+
+	if u.MaxClicks == 0 {
+		return u.URL, nil
+	}
 
-	stmt, err := s.db.Prepare("DELETE FROM url WHERE alias=?")
+	// the count is checked again as concurrent clicks may have used up
+	// the link since it was read
+	res, err := s.db.Exec("UPDATE url SET click_count = click_count + 1 WHERE id = ? AND click_count < max_clicks", u.ID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", err
 	}
 
-	_, err = stmt.Exec(alias)
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return "", storage.ErrURLExpired
 	}
 
-	return nil
+	return u.URL, nil
 }
 
-func (s *Storage) SaveGoods(title string, price float64, description string, imgUrl string, weight int32) (int64, error) {
-	const op = "storage.sqlite.SaveGoods"
+func getURL(q queryRower, alias string) (storage.URL, error) {
+	var (
+		u         storage.URL
+		expiresAt sql.NullTime
+	)
 
-	stmt, err := s.db.Prepare("INSERT INTO goods(title, price, description, imgUrl, weight) VALUES (?, ?, ?, ?, ?)")
+	err := q.QueryRow(`
+		SELECT id, alias, url, expires_at, max_clicks, click_count, password_hash, version
+		FROM url
+		WHERE alias = ? AND deleted_at IS NULL`, alias).
+		Scan(&u.ID, &u.Alias, &u.URL, &expiresAt, &u.MaxClicks, &u.ClickCount, &u.PasswordHash, &u.Version)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return storage.URL{}, err
+	}
+
+	u.ExpiresAt = expiresAt.Time
+
+	return u, nil
+}
+
+// DeleteURL moves the url to the trash. Its alias stays taken until the
+// url is purged.
+func (s *Storage) DeleteURL(alias string) error {
+	const op = "storage.sqlite.DeleteURL"
+
+	stmt, err := s.db.Prepare("UPDATE url SET deleted_at = CURRENT_TIMESTAMP WHERE alias = ? AND deleted_at IS NULL")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	res, err := stmt.Exec(title, price, description, imgUrl, weight)
+	res, err := stmt.Exec(alias)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	id, err := res.LastInsertId()
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrURLNotFound
 	}
 
-	return id, nil
+	return nil
+}
+
+// nullUnix converts t to a Unix time for datetime(?, 'unixepoch'), which
+// is NULL for a zero t.
+func nullUnix(t time.Time) sql.NullInt64 {
+	return sql.NullInt64{Int64: t.Unix(), Valid: !t.IsZero()}
 }
@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/storage"
+)
+
+func newTestStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	s, err := New(filepath.Join(t.TempDir(), "storage.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { s.db.Close() })
+
+	return s
+}
+
+func TestNewMigrates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "storage.db")
+
+	// the second run must find the schema up to date
+	for i := 0; i < 2; i++ {
+		s, err := New(path)
+		require.NoError(t, err)
+
+		var version int
+		require.NoError(t, s.db.QueryRow("PRAGMA user_version").Scan(&version))
+		assert.Equal(t, len(migrations), version)
+
+		require.NoError(t, s.db.Close())
+	}
+}
+
+func TestURLAutoincrementMigration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "storage.db")
+
+	s, err := New(path)
+	require.NoError(t, err)
+
+	_, err = s.SaveURL(storage.URL{Alias: "alias", URL: "https://example.com/first"})
+	require.NoError(t, err)
+
+	_, err = s.UpdateURL(storage.URL{Alias: "alias", URL: "https://example.com/second"}, 0, "test")
+	require.NoError(t, err)
+
+	// run the rebuild of url again over the saved url
+	_, err = s.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", len(migrations)-1))
+	require.NoError(t, err)
+	require.NoError(t, s.db.Close())
+
+	s, err = New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.db.Close() })
+
+	url, err := s.LookupURL("alias")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/second", url.URL)
+	assert.Equal(t, int64(2), url.Version)
+
+	history, err := s.ListURLHistory("alias", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "https://example.com/first", history[0].URL)
+}
+
+func TestSearchGoods(t *testing.T) {
+	s := newTestStorage(t)
+
+	for _, g := range []storage.Goods{
+		{Title: "Red apple", Price: 100, Currency: "USD", Description: "Sweet and crunchy", Weight: 150},
+		{Title: "Green pear", Price: 200, Currency: "USD", Description: "Goes well with an apple pie", Weight: 200},
+		{Title: "Banana", Price: 300, Currency: "EUR", Weight: 120},
+	} {
+		_, err := s.SaveGoods(g, "test")
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name   string
+		opts   storage.GoodsSearchOptions
+		titles []string
+	}{
+		{
+			name:   "Title and description",
+			opts:   storage.GoodsSearchOptions{Query: "apple"},
+			titles: []string{"Red apple", "Green pear"},
+		},
+		{
+			name:   "All words",
+			opts:   storage.GoodsSearchOptions{Query: "apple pie"},
+			titles: []string{"Green pear"},
+		},
+		{
+			name:   "Filtered",
+			opts:   storage.GoodsSearchOptions{Query: "apple", Currency: "EUR"},
+			titles: []string{},
+		},
+		{
+			name:   "No match",
+			opts:   storage.GoodsSearchOptions{Query: "cherry"},
+			titles: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.Limit = 10
+
+			results, err := s.SearchGoods(tc.opts)
+			require.NoError(t, err)
+
+			titles := make([]string, 0, len(results))
+			for _, r := range results {
+				titles = append(titles, r.Title)
+			}
+
+			assert.Equal(t, tc.titles, titles)
+		})
+	}
+}
+
+func TestSearchGoodsEscapesHTML(t *testing.T) {
+	s := newTestStorage(t)
+
+	_, err := s.SaveGoods(storage.Goods{
+		Title:       `Apple <img src=x onerror=alert(1)>`,
+		Price:       100,
+		Currency:    "USD",
+		Description: `Crunchy apple & <b>sweet</b>`,
+		Weight:      150,
+	}, "test")
+	require.NoError(t, err)
+
+	results, err := s.SearchGoods(storage.GoodsSearchOptions{Query: "apple", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	if !s.fts {
+		assert.Equal(t, `Apple &lt;img src=x onerror=alert(1)&gt;`, results[0].Highlight)
+
+		return
+	}
+
+	assert.Equal(t, `<mark>Apple</mark> &lt;img src=x onerror=alert(1)&gt;`, results[0].Highlight)
+	assert.Equal(t, `Crunchy <mark>apple</mark> &amp; &lt;b&gt;sweet&lt;/b&gt;`, results[0].Snippet)
+}
@@ -0,0 +1,199 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go-api/internal/storage"
+)
+
+// stockUpdates maps a movement kind to the update it makes. Every update
+// is guarded so that neither the stock on hand nor the available stock
+// can drop below zero; a guard failure leaves no row affected.
+var stockUpdates = map[string]string{
+	storage.StockReceive: "UPDATE goods SET stock = stock + ?1 WHERE id = ?2",
+	storage.StockAdjust:  "UPDATE goods SET stock = stock + ?1 WHERE id = ?2 AND stock + ?1 >= reserved",
+	storage.StockReserve: "UPDATE goods SET reserved = reserved + ?1 WHERE id = ?2 AND stock - reserved >= ?1",
+	storage.StockRelease: "UPDATE goods SET reserved = reserved - ?1 WHERE id = ?2 AND reserved >= ?1",
+	storage.StockCommit:  "UPDATE goods SET stock = stock - ?1, reserved = reserved - ?1 WHERE id = ?2 AND reserved >= ?1",
+}
+
+func (s *Storage) GetStock(goodsID int64) (storage.Stock, error) {
+	const op = "storage.sqlite.GetStock"
+
+	stock, err := getStock(s.db, goodsID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Stock{}, storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stock, nil
+}
+
+// MoveStock atomically applies a stock movement and records it in the
+// ledger. Only adjustments may have a negative quantity.
+func (s *Storage) MoveStock(goodsID int64, kind string, quantity int64, reference string) (storage.Stock, error) {
+	const op = "storage.sqlite.MoveStock"
+
+	update, ok := stockUpdates[kind]
+	if !ok || quantity == 0 || (quantity < 0 && kind != storage.StockAdjust) {
+		return storage.Stock{}, storage.ErrInvalidStockMovement
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(update, quantity, goodsID)
+	if err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	stock, err := getStock(tx, goodsID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Stock{}, storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.Stock{}, storage.ErrInsufficientStock
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO stock_movements(goods_id, kind, quantity, stock_after, reserved_after, reference)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		goodsID, kind, quantity, stock.OnHand, stock.Reserved, reference)
+	if err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.Stock{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stock, nil
+}
+
+func (s *Storage) SetLowStockThreshold(goodsID int64, threshold int64) error {
+	const op = "storage.sqlite.SetLowStockThreshold"
+
+	stmt, err := s.db.Prepare("UPDATE goods SET low_stock_threshold = ? WHERE id = ? AND deleted_at IS NULL")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(threshold, goodsID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrGoodsNotFound
+	}
+
+	return nil
+}
+
+// ListStockMovements returns the ledger of the goods, newest first.
+func (s *Storage) ListStockMovements(goodsID int64, limit int, offset int) ([]storage.StockMovement, error) {
+	const op = "storage.sqlite.ListStockMovements"
+
+	rows, err := s.db.Query(`
+		SELECT id, goods_id, kind, quantity, stock_after, reserved_after, reference, created_at
+		FROM stock_movements
+		WHERE goods_id = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?`, goodsID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	movements := make([]storage.StockMovement, 0, limit)
+
+	for rows.Next() {
+		var m storage.StockMovement
+
+		err := rows.Scan(&m.ID, &m.GoodsID, &m.Kind, &m.Quantity, &m.OnHand, &m.Reserved, &m.Reference, &m.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		movements = append(movements, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return movements, nil
+}
+
+// ListLowStock returns goods whose available stock has fallen to their
+// low-stock threshold, scarcest first. Goods without a threshold are
+// never reported.
+func (s *Storage) ListLowStock(limit int, offset int) ([]storage.LowStockItem, error) {
+	const op = "storage.sqlite.ListLowStock"
+
+	rows, err := s.db.Query(`
+		SELECT `+goodsColumns+`, g.stock, g.reserved, g.low_stock_threshold
+		FROM goods g
+		WHERE g.deleted_at IS NULL AND g.low_stock_threshold > 0 AND g.stock - g.reserved <= g.low_stock_threshold
+		ORDER BY g.stock - g.reserved, g.id
+		LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	items := make([]storage.LowStockItem, 0, limit)
+
+	for rows.Next() {
+		var (
+			g  storage.Goods
+			st storage.Stock
+		)
+
+		err := rows.Scan(append(goodsFields(&g), &st.OnHand, &st.Reserved, &st.LowStockThreshold)...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		st.GoodsID = g.ID
+
+		items = append(items, storage.LowStockItem{Goods: g, Stock: st})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, nil
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func getStock(q queryRower, goodsID int64) (storage.Stock, error) {
+	stock := storage.Stock{GoodsID: goodsID}
+
+	err := q.QueryRow("SELECT stock, reserved, low_stock_threshold FROM goods WHERE id = ? AND deleted_at IS NULL", goodsID).
+		Scan(&stock.OnHand, &stock.Reserved, &stock.LowStockThreshold)
+
+	return stock, err
+}
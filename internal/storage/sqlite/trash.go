@@ -0,0 +1,197 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-api/internal/storage"
+)
+
+// ListDeletedURLs returns the urls in the trash, most recently deleted
+// first.
+func (s *Storage) ListDeletedURLs(limit int, offset int) ([]storage.URL, error) {
+	const op = "storage.sqlite.ListDeletedURLs"
+
+	rows, err := s.db.Query(`
+		SELECT id, alias, url, deleted_at
+		FROM url
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	urls := make([]storage.URL, 0, limit)
+
+	for rows.Next() {
+		var u storage.URL
+
+		if err := rows.Scan(&u.ID, &u.Alias, &u.URL, &u.DeletedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		urls = append(urls, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return urls, nil
+}
+
+// UndeleteURL takes the url out of the trash.
+func (s *Storage) UndeleteURL(alias string) error {
+	const op = "storage.sqlite.UndeleteURL"
+
+	res, err := s.db.Exec("UPDATE url SET deleted_at = NULL WHERE alias = ? AND deleted_at IS NOT NULL", alias)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrURLNotFound
+	}
+
+	return nil
+}
+
+// PurgeURLs permanently deletes the urls moved to the trash before the
+// given time, with their clicks, and returns how many there were.
+func (s *Storage) PurgeURLs(before time.Time) (int64, error) {
+	const op = "storage.sqlite.PurgeURLs"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// the clicks go first, so that a new url with a purged alias starts
+	// without stats
+	_, err = tx.Exec(`
+		DELETE FROM clicks
+		WHERE alias IN (SELECT alias FROM url WHERE deleted_at < datetime(?, 'unixepoch'))`, before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec("DELETE FROM url WHERE deleted_at < datetime(?, 'unixepoch')", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
+
+// ListDeletedGoods returns the goods in the trash, most recently deleted
+// first.
+func (s *Storage) ListDeletedGoods(limit int, offset int) ([]storage.Goods, error) {
+	const op = "storage.sqlite.ListDeletedGoods"
+
+	rows, err := s.db.Query(`
+		SELECT `+goodsColumns+`, g.deleted_at
+		FROM goods g
+		WHERE g.deleted_at IS NOT NULL
+		ORDER BY g.deleted_at DESC, g.id DESC
+		LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	goods := make([]storage.Goods, 0, limit)
+
+	for rows.Next() {
+		var g storage.Goods
+
+		if err := rows.Scan(append(goodsFields(&g), &g.DeletedAt)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		goods = append(goods, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return goods, nil
+}
+
+// UndeleteGoods takes the goods out of the trash and records it in the
+// goods history as restored by actor.
+func (s *Storage) UndeleteGoods(id int64, actor string) (storage.Goods, error) {
+	const op = "storage.sqlite.UndeleteGoods"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE goods SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.Goods{}, storage.ErrGoodsNotFound
+	}
+
+	goods, err := getGoods(tx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.Goods{}, storage.ErrGoodsNotFound
+	}
+	if err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordGoodsHistory(tx, storage.GoodsRestored, actor, nil, &goods); err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.Goods{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return goods, nil
+}
+
+// PurgeGoods permanently deletes the goods moved to the trash before the
+// given time, along with their categories, prices and variants, and
+// returns how many there were. Their history is kept.
+func (s *Storage) PurgeGoods(before time.Time) (int64, error) {
+	const op = "storage.sqlite.PurgeGoods"
+
+	res, err := s.db.Exec("DELETE FROM goods WHERE deleted_at < datetime(?, 'unixepoch')", before.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,372 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-api/internal/storage"
+)
+
+// maxAliasAttempts is how many aliases are generated for an url before
+// giving up on collisions with the aliases taken.
+const maxAliasAttempts = 5
+
+// SaveGeneratedURL saves the url with the alias that generate makes from
+// the id it is saved with. While the alias is taken, the url moves on to
+// a new id and alias, up to maxAliasAttempts times.
+func (s *Storage) SaveGeneratedURL(url storage.URL, generate func(id int64) (string, error)) (storage.URL, error) {
+	const op = "storage.sqlite.SaveGeneratedURL"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	url, err = insertGeneratedURL(tx, url, generate)
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return url, nil
+}
+
+// SaveURLBatch inserts the urls in a single transaction; those without an
+// alias get one from generate, like with SaveGeneratedURL, and it is set
+// in urls. An url whose alias is taken gets storage.ErrURLExists at its
+// index of the returned errors without stopping the others, unless atomic
+// is set: then nothing is saved if any of them fails.
+func (s *Storage) SaveURLBatch(urls []storage.URL, atomic bool, generate func(id int64) (string, error)) ([]error, error) {
+	const op = "storage.sqlite.SaveURLBatch"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(insertURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		errs   = make([]error, len(urls))
+		failed bool
+	)
+
+	for i, url := range urls {
+		if url.Alias == "" {
+			urls[i], err = insertGeneratedURL(tx, url, generate)
+		} else {
+			// a failed insert only undoes itself, the transaction goes on
+			_, err = stmt.Exec(url.URL, url.Alias, nullUnix(url.ExpiresAt), url.MaxClicks, url.PasswordHash)
+			if isUniqueErr(err) {
+				err = storage.ErrURLExists
+			}
+		}
+		if errors.Is(err, storage.ErrURLExists) {
+			errs[i], failed = storage.ErrURLExists, true
+
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if atomic && failed {
+		return errs, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return errs, nil
+}
+
+// insertGeneratedURL inserts the url and sets the alias that generate
+// makes from its id. The url is inserted with an empty alias first, which
+// no saved url has, to get the id; while the alias is taken, it is
+// inserted again under a new id, as ids are never reused.
+func insertGeneratedURL(tx *sql.Tx, url storage.URL, generate func(id int64) (string, error)) (storage.URL, error) {
+	for attempt := 0; attempt < maxAliasAttempts; attempt++ {
+		res, err := tx.Exec(insertURL, url.URL, "", nullUnix(url.ExpiresAt), url.MaxClicks, url.PasswordHash)
+		if err != nil {
+			return storage.URL{}, err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return storage.URL{}, err
+		}
+
+		alias, err := generate(id)
+		if err != nil {
+			return storage.URL{}, err
+		}
+
+		_, err = tx.Exec("UPDATE url SET alias = ? WHERE id = ?", alias, id)
+		if isUniqueErr(err) {
+			if _, err := tx.Exec("DELETE FROM url WHERE id = ?", id); err != nil {
+				return storage.URL{}, err
+			}
+
+			continue
+		}
+		if err != nil {
+			return storage.URL{}, err
+		}
+
+		url.ID, url.Alias = id, alias
+
+		return url, nil
+	}
+
+	return storage.URL{}, storage.ErrURLExists
+}
+
+// ListURLs returns a page of the urls, with the number of redirects
+// recorded for each.
+func (s *Storage) ListURLs(opts storage.URLListOptions) ([]storage.URLSummary, error) {
+	const op = "storage.sqlite.ListURLs"
+
+	var (
+		where = []string{"u.deleted_at IS NULL"}
+		args  []any
+	)
+
+	if opts.Query != "" {
+		pattern := escapeLike(opts.Query) + "%"
+		if !opts.Prefix {
+			pattern = "%" + pattern
+		}
+
+		where = append(where, `(u.alias LIKE ? ESCAPE '\' OR u.url LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	if !opts.CreatedFrom.IsZero() {
+		where = append(where, "u.created_at >= datetime(?, 'unixepoch')")
+		args = append(args, opts.CreatedFrom.Unix())
+	}
+
+	if !opts.CreatedTo.IsZero() {
+		where = append(where, "u.created_at < datetime(?, 'unixepoch')")
+		args = append(args, opts.CreatedTo.Unix())
+	}
+
+	order := "ASC"
+	if opts.Desc {
+		order = "DESC"
+	}
+
+	query := `
+		SELECT u.id, u.alias, u.url, u.created_at,
+			(SELECT COUNT(*) FROM clicks c WHERE c.alias = u.alias) AS clicks
+		FROM url u
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + urlSortColumn(opts.SortBy) + " " + order + ", u.id " + order + `
+		LIMIT ? OFFSET ?`
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	urls := make([]storage.URLSummary, 0, opts.Limit)
+
+	for rows.Next() {
+		var u storage.URLSummary
+
+		if err := rows.Scan(&u.ID, &u.Alias, &u.URL.URL, &u.CreatedAt, &u.Clicks); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		urls = append(urls, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return urls, nil
+}
+
+func urlSortColumn(sortBy string) string {
+	switch sortBy {
+	case "alias":
+		return "u.alias"
+	case "url":
+		return "u.url"
+	case "clicks":
+		return "clicks"
+	default:
+		return "u.created_at"
+	}
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, with \ as the
+// escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// LookupURL returns the url with the alias, whether it has expired or is
+// protected or not, without counting a click.
+func (s *Storage) LookupURL(alias string) (storage.URL, error) {
+	const op = "storage.sqlite.LookupURL"
+
+	url, err := getURL(s.db, alias)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.URL{}, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return url, nil
+}
+
+// UpdateURL replaces the target, expiry and password of the url with the
+// alias of url, keeping its clicks. The previous target is kept in the
+// url history as replaced by actor. A zero version updates whatever the
+// current version is, otherwise it must match it.
+func (s *Storage) UpdateURL(url storage.URL, version int64, actor string) (storage.URL, error) {
+	const op = "storage.sqlite.UpdateURL"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	current, err := getURL(tx, url.Alias)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.URL{}, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if version != 0 && current.Version != version {
+		return storage.URL{}, storage.ErrURLVersionMismatch
+	}
+
+	updated, err := updateURL(tx, current, url, actor)
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// ListURLHistory returns the previous targets of the url, newest first.
+func (s *Storage) ListURLHistory(alias string, limit int, offset int) ([]storage.URLRevision, error) {
+	const op = "storage.sqlite.ListURLHistory"
+
+	rows, err := s.db.Query(`
+		SELECT h.id, h.version, h.url, h.actor, h.replaced_at
+		FROM url_history h
+		JOIN url u ON u.id = h.url_id
+		WHERE u.alias = ? AND u.deleted_at IS NULL
+		ORDER BY h.version DESC
+		LIMIT ? OFFSET ?`, alias, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	revisions := make([]storage.URLRevision, 0, limit)
+
+	for rows.Next() {
+		var rev storage.URLRevision
+
+		if err := rows.Scan(&rev.ID, &rev.Version, &rev.URL, &rev.Actor, &rev.ReplacedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+// RollbackURL retargets the url to its target at version, as a new
+// version; the expiry and password of the url are left as they are.
+func (s *Storage) RollbackURL(alias string, version int64, actor string) (storage.URL, error) {
+	const op = "storage.sqlite.RollbackURL"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	current, err := getURL(tx, alias)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.URL{}, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	target := current
+
+	err = tx.QueryRow("SELECT url FROM url_history WHERE url_id = ? AND version = ?", current.ID, version).
+		Scan(&target.URL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.URL{}, storage.ErrURLRevisionNotFound
+	}
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := updateURL(tx, current, target, actor)
+	if err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return storage.URL{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// updateURL writes url over current as its next version and records the
+// target of current in the url history.
+func updateURL(tx *sql.Tx, current storage.URL, url storage.URL, actor string) (storage.URL, error) {
+	_, err := tx.Exec("INSERT INTO url_history(url_id, version, url, actor) VALUES (?, ?, ?, ?)",
+		current.ID, current.Version, current.URL, actor)
+	if err != nil {
+		return storage.URL{}, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE url
+		SET url = ?, expires_at = datetime(?, 'unixepoch'), max_clicks = ?, password_hash = ?, version = version + 1
+		WHERE id = ?`,
+		url.URL, nullUnix(url.ExpiresAt), url.MaxClicks, url.PasswordHash, current.ID)
+	if err != nil {
+		return storage.URL{}, err
+	}
+
+	return getURL(tx, current.Alias)
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-api/internal/storage"
+)
+
+func TestUpdateRollbackURLConcurrently(t *testing.T) {
+	const n = 16
+
+	s := newTestStorage(t)
+
+	errs := concurrently(n, func(i int) error {
+		alias := fmt.Sprintf("alias%d", i)
+
+		if _, err := s.SaveURL(storage.URL{Alias: alias, URL: "https://example.com/first"}); err != nil {
+			return err
+		}
+
+		url, err := s.UpdateURL(storage.URL{Alias: alias, URL: "https://example.com/second"}, 0, "test")
+		if err != nil {
+			return err
+		}
+
+		_, err = s.RollbackURL(alias, url.Version-1, "test")
+
+		return err
+	})
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func generateID(id int64) (string, error) {
+	return strconv.FormatInt(id, 10), nil
+}
+
+func TestSaveGeneratedURL(t *testing.T) {
+	s := newTestStorage(t)
+
+	first, err := s.SaveGeneratedURL(storage.URL{URL: "https://example.com"}, generateID)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(first.ID, 10), first.Alias)
+
+	// the alias of the next id is taken
+	_, err = s.SaveURL(storage.URL{Alias: strconv.FormatInt(first.ID+2, 10), URL: "https://example.com"})
+	require.NoError(t, err)
+
+	second, err := s.SaveGeneratedURL(storage.URL{URL: "https://example.com"}, generateID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID+3, second.ID)
+	assert.Equal(t, strconv.FormatInt(second.ID, 10), second.Alias)
+
+	// a purged url gives its id, and so its alias, to nobody
+	_, err = s.db.Exec("DELETE FROM url WHERE id = ?", second.ID)
+	require.NoError(t, err)
+
+	third, err := s.SaveGeneratedURL(storage.URL{URL: "https://example.com"}, generateID)
+	require.NoError(t, err)
+	assert.Greater(t, third.ID, second.ID)
+	assert.NotEqual(t, second.Alias, third.Alias)
+}
+
+func TestSaveGeneratedURLConcurrently(t *testing.T) {
+	const n = 16
+
+	s := newTestStorage(t)
+
+	aliases := make([]string, n)
+
+	errs := concurrently(n, func(i int) error {
+		url, err := s.SaveGeneratedURL(storage.URL{URL: "https://example.com"}, generateID)
+		aliases[i] = url.Alias
+
+		return err
+	})
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	seen := make(map[string]bool, n)
+	for _, alias := range aliases {
+		assert.False(t, seen[alias], "alias %q given twice", alias)
+		seen[alias] = true
+	}
+}
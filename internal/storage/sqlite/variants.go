@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+
+	"go-api/internal/storage"
+)
+
+const variantColumns = "id, goods_id, sku, options, price, weight, stock"
+
+func (s *Storage) SaveVariant(variant storage.Variant) (int64, error) {
+	const op = "storage.sqlite.SaveVariant"
+
+	options, err := json.Marshal(variantOptions(variant.Options))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err := s.db.Prepare("INSERT INTO goods_variants(goods_id, sku, options, price, weight, stock) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(variant.GoodsID, variant.SKU, string(options), variant.Price, variant.Weight, variant.Stock)
+	if err != nil {
+		if isForeignKeyErr(err) {
+			return 0, storage.ErrGoodsNotFound
+		}
+		if isUniqueErr(err) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrVariantExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetVariant(goodsID int64, sku string) (storage.Variant, error) {
+	const op = "storage.sqlite.GetVariant"
+
+	rows, err := s.db.Query("SELECT "+variantColumns+" FROM goods_variants WHERE goods_id = ? AND sku = ?", goodsID, sku)
+	if err != nil {
+		return storage.Variant{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	variants, err := scanVariants(rows)
+	if err != nil {
+		return storage.Variant{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(variants) == 0 {
+		return storage.Variant{}, storage.ErrVariantNotFound
+	}
+
+	return variants[0], nil
+}
+
+func (s *Storage) ListVariants(goodsID int64) ([]storage.Variant, error) {
+	const op = "storage.sqlite.ListVariants"
+
+	rows, err := s.db.Query("SELECT "+variantColumns+" FROM goods_variants WHERE goods_id = ? ORDER BY id", goodsID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	variants, err := scanVariants(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return variants, nil
+}
+
+// ListVariantsByGoods returns the variants of a page of goods at once,
+// grouped by goods id.
+func (s *Storage) ListVariantsByGoods(goodsIDs []int64) (map[int64][]storage.Variant, error) {
+	const op = "storage.sqlite.ListVariantsByGoods"
+
+	byGoods := make(map[int64][]storage.Variant, len(goodsIDs))
+
+	if len(goodsIDs) == 0 {
+		return byGoods, nil
+	}
+
+	args := make([]any, 0, len(goodsIDs))
+	for _, id := range goodsIDs {
+		args = append(args, id)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(goodsIDs)), ", ")
+
+	rows, err := s.db.Query("SELECT "+variantColumns+" FROM goods_variants WHERE goods_id IN ("+placeholders+") ORDER BY id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	variants, err := scanVariants(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, v := range variants {
+		byGoods[v.GoodsID] = append(byGoods[v.GoodsID], v)
+	}
+
+	return byGoods, nil
+}
+
+// UpdateVariant replaces the options, overrides and stock of the variant
+// with the goods id and SKU of variant.
+func (s *Storage) UpdateVariant(variant storage.Variant) error {
+	const op = "storage.sqlite.UpdateVariant"
+
+	options, err := json.Marshal(variantOptions(variant.Options))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	stmt, err := s.db.Prepare("UPDATE goods_variants SET options = ?, price = ?, weight = ?, stock = ? WHERE goods_id = ? AND sku = ?")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(string(options), variant.Price, variant.Weight, variant.Stock, variant.GoodsID, variant.SKU)
+	if err != nil {
+		if isUniqueErr(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrVariantExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteVariant(goodsID int64, sku string) error {
+	const op = "storage.sqlite.DeleteVariant"
+
+	stmt, err := s.db.Prepare("DELETE FROM goods_variants WHERE goods_id = ? AND sku = ?")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := stmt.Exec(goodsID, sku)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func scanVariants(rows *sql.Rows) ([]storage.Variant, error) {
+	variants := []storage.Variant{}
+
+	for rows.Next() {
+		var (
+			v       storage.Variant
+			options string
+			price   sql.NullInt64
+			weight  sql.NullInt32
+		)
+
+		if err := rows.Scan(&v.ID, &v.GoodsID, &v.SKU, &options, &price, &weight, &v.Stock); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(options), &v.Options); err != nil {
+			return nil, err
+		}
+		if price.Valid {
+			v.Price = &price.Int64
+		}
+		if weight.Valid {
+			v.Weight = &weight.Int32
+		}
+
+		variants = append(variants, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// variantOptions makes a variant without options marshal as {} rather
+// than null.
+func variantOptions(options map[string]string) map[string]string {
+	if options == nil {
+		return map[string]string{}
+	}
+
+	return options
+}
+
+func isUniqueErr(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
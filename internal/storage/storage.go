@@ -1,8 +1,37 @@
-package storage
-
-import "errors"
-
-var (
-	ErrURLNotFound = errors.New("url not found")
-	ErrURLExists   = errors.New("url exists")
-)
+package storage
+
+import "errors"
+
+var (
+	ErrURLNotFound           = errors.New("url not found")
+	ErrURLExists             = errors.New("url exists")
+	ErrURLExpired            = errors.New("url expired")
+	ErrURLProtected          = errors.New("url is password protected")
+	ErrURLVersionMismatch    = errors.New("url version mismatch")
+	ErrURLRevisionNotFound   = errors.New("url revision not found")
+	ErrGoodsNotFound         = errors.New("goods not found")
+	ErrGoodsVersionMismatch  = errors.New("goods version mismatch")
+	ErrGoodsRevisionNotFound = errors.New("goods revision not found")
+	ErrCategoryNotFound      = errors.New("category not found")
+	ErrCategoryHasChildren   = errors.New("category has subcategories")
+	ErrCategoryCycle         = errors.New("category cannot be nested into itself")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrInvalidStockMovement  = errors.New("invalid stock movement")
+	ErrPriceNotFound         = errors.New("price not found")
+	ErrBaseCurrencyPrice     = errors.New("price in the goods currency is set on the goods itself")
+	ErrImageNotFound         = errors.New("image not found")
+	ErrVariantNotFound       = errors.New("variant not found")
+	ErrVariantExists         = errors.New("variant with this sku or options exists")
+	ErrCartNotFound          = errors.New("cart not found")
+	ErrCartItemNotFound      = errors.New("cart item not found")
+	ErrCartEmpty             = errors.New("cart is empty")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrOrderStatus           = errors.New("order status change not allowed")
+	ErrPromotionNotFound     = errors.New("promotion not found")
+	ErrPromotionExists       = errors.New("promotion with this code exists")
+	ErrPromotionInactive     = errors.New("promotion is not active")
+	ErrPromotionUsedUp       = errors.New("promotion usage limit reached")
+	ErrPromotionCurrency     = errors.New("promotion does not apply to this currency")
+	ErrPromotionNoGoods      = errors.New("promotion does not apply to any of the goods")
+	ErrReviewNotFound        = errors.New("review not found")
+)